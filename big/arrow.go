@@ -0,0 +1,246 @@
+package big
+
+import (
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+
+	"github.com/golang-plus/errors"
+)
+
+// Apache Arrow stores decimal128/decimal256 values as little-endian two's-complement integers of 16/32 bytes,
+// the unscaled integer of the value with precision and scale declared by the column type.
+
+const (
+	// ArrowDecimal128Size is the number of bytes of an Arrow decimal128 value.
+	ArrowDecimal128Size = 16
+	// ArrowDecimal256Size is the number of bytes of an Arrow decimal256 value.
+	ArrowDecimal256Size = 32
+
+	_ArrowDecimal128MaxPrecision = 38
+	_ArrowDecimal256MaxPrecision = 76
+)
+
+func checkArrowDecimalType(precision, scale, maxPrecision int) error {
+	if precision < 1 || precision > maxPrecision || scale < 0 || scale > precision {
+		return errors.Newf("decimal(%d,%d) is invalid (max precision is %d)", precision, scale, maxPrecision)
+	}
+	return nil
+}
+
+// putArrowDecimal puts d (rescaled to scale) into b as a little-endian two's-complement integer.
+func putArrowDecimal(b []byte, d *Decimal, precision, scale int) error {
+	buf := getInt()
+	defer putInt(buf)
+	c := d.coefficient(buf)
+	shift := d.exponent + scale
+	if c.IsInt64() && shift >= -19 && shift <= 18 { // fast path: the unscaled integer fits in 128 bits
+		x := c.Int64()
+		negative := x < 0
+		mag := uint64(x)
+		if negative {
+			mag = -mag
+		}
+		var hi, lo uint64
+		if shift >= 0 {
+			hi, lo = bits.Mul64(mag, _Pow10Uint64[shift])
+		} else {
+			if mag%_Pow10Uint64[shift*-1] != 0 {
+				return errors.Newf("decimal has more than %d decimal digits", scale)
+			}
+			lo = mag / _Pow10Uint64[shift*-1]
+		}
+		if precision < 39 && !lessThanPow10(hi, lo, precision) {
+			return errors.Newf("decimal overflows decimal(%d,%d)", precision, scale)
+		}
+		if negative { // two's complement
+			lo, hi = ^lo+1, ^hi
+			if lo == 0 {
+				hi++
+			}
+		}
+		binary.LittleEndian.PutUint64(b[0:], lo)
+		binary.LittleEndian.PutUint64(b[8:], hi)
+		ext := byte(0)
+		if negative {
+			ext = 0xFF
+		}
+		for i := 16; i < len(b); i++ {
+			b[i] = ext
+		}
+		return nil
+	}
+
+	unscaled, exact, fits := d.scaledIntegerWithin(precision, scale)
+	if !exact {
+		return errors.Newf("decimal has more than %d decimal digits", scale)
+	}
+	if !fits {
+		return errors.Newf("decimal overflows decimal(%d,%d)", precision, scale)
+	}
+	if unscaled.Sign() < 0 {
+		unscaled.Add(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+	}
+	unscaled.FillBytes(b)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 { // to little-endian
+		b[i], b[j] = b[j], b[i]
+	}
+	return nil
+}
+
+// lessThanPow10 reports whether the 128 bits unsigned integer hi:lo is less than 10**n (n <= 38).
+func lessThanPow10(hi, lo uint64, n int) bool {
+	if n < len(_Pow10Uint64) {
+		return hi == 0 && lo < _Pow10Uint64[n]
+	}
+	phi, plo := bits.Mul64(_Pow10Uint64[19], _Pow10Uint64[n-19])
+	return hi < phi || hi == phi && lo < plo
+}
+
+// getArrowDecimal returns the decimal of the little-endian two's-complement integer b with given precision and scale.
+func getArrowDecimal(b []byte, precision, scale int) (*Decimal, error) {
+	d := new(Decimal)
+	lo := binary.LittleEndian.Uint64(b[0:])
+	ext := uint64(0)
+	if int64(lo) < 0 {
+		ext = ^ext
+	}
+	fits := true // fits in int64
+	for i := 8; i < len(b); i += 8 {
+		if binary.LittleEndian.Uint64(b[i:]) != ext {
+			fits = false
+			break
+		}
+	}
+	if fits {
+		x := int64(lo)
+		mag := uint64(x)
+		if x < 0 {
+			mag = -mag
+		}
+		if precision < len(_Pow10Uint64) && mag >= _Pow10Uint64[precision] {
+			return nil, errors.Newf("decimal data overflows decimal(%d,%d)", precision, scale)
+		}
+		d.small, d.inline = x, true // no big.Int needed
+	} else {
+		d.ensureInitialized()
+		be := make([]byte, len(b))
+		for i := range b {
+			be[len(b)-1-i] = b[i]
+		}
+		d.integer.SetBytes(be)
+		if b[len(b)-1]&0x80 != 0 { // negative
+			d.integer.Sub(d.integer, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+		}
+		if d.integer.CmpAbs(pow10(precision)) >= 0 {
+			return nil, errors.Newf("decimal data overflows decimal(%d,%d)", precision, scale)
+		}
+	}
+	d.exponent = scale * -1
+	d.trimFraction()
+	return d, nil
+}
+
+// PutArrowDecimal128 puts d as Arrow decimal128(precision,scale) into b (at least ArrowDecimal128Size bytes).
+// An error is returned if d has more decimal digits than scale or more digits than precision (it is not rounded).
+func PutArrowDecimal128(b []byte, d *Decimal, precision, scale int) error {
+	if err := checkArrowDecimalType(precision, scale, _ArrowDecimal128MaxPrecision); err != nil {
+		return err
+	}
+	return putArrowDecimal(b[:ArrowDecimal128Size], d, precision, scale)
+}
+
+// ArrowDecimal128 returns a new decimal by decoding Arrow decimal128(precision,scale) from b (at least ArrowDecimal128Size bytes).
+func ArrowDecimal128(b []byte, precision, scale int) (*Decimal, error) {
+	if err := checkArrowDecimalType(precision, scale, _ArrowDecimal128MaxPrecision); err != nil {
+		return nil, err
+	}
+	return getArrowDecimal(b[:ArrowDecimal128Size], precision, scale)
+}
+
+// PutArrowDecimal256 puts d as Arrow decimal256(precision,scale) into b (at least ArrowDecimal256Size bytes).
+// An error is returned if d has more decimal digits than scale or more digits than precision (it is not rounded).
+func PutArrowDecimal256(b []byte, d *Decimal, precision, scale int) error {
+	if err := checkArrowDecimalType(precision, scale, _ArrowDecimal256MaxPrecision); err != nil {
+		return err
+	}
+	return putArrowDecimal(b[:ArrowDecimal256Size], d, precision, scale)
+}
+
+// ArrowDecimal256 returns a new decimal by decoding Arrow decimal256(precision,scale) from b (at least ArrowDecimal256Size bytes).
+func ArrowDecimal256(b []byte, precision, scale int) (*Decimal, error) {
+	if err := checkArrowDecimalType(precision, scale, _ArrowDecimal256MaxPrecision); err != nil {
+		return nil, err
+	}
+	return getArrowDecimal(b[:ArrowDecimal256Size], precision, scale)
+}
+
+// putArrowDecimalColumn puts values into buf (len(values)*size bytes), nil values (nulls) are put as zero.
+func putArrowDecimalColumn(buf []byte, values []*Decimal, precision, scale, size int) error {
+	if len(buf) != len(values)*size {
+		return errors.Newf("buffer of %d values must be %d bytes", len(values), len(values)*size)
+	}
+	for i, v := range values {
+		b := buf[i*size : (i+1)*size]
+		if v == nil {
+			for j := range b {
+				b[j] = 0
+			}
+			continue
+		}
+		if err := putArrowDecimal(b, v, precision, scale); err != nil {
+			return errors.Newf("value %d: %s", i, err)
+		}
+	}
+	return nil
+}
+
+// getArrowDecimalColumn returns the values of buf (a multiple of size bytes).
+func getArrowDecimalColumn(buf []byte, precision, scale, size int) ([]*Decimal, error) {
+	if len(buf)%size != 0 {
+		return nil, errors.Newf("buffer size %d is not a multiple of %d", len(buf), size)
+	}
+	values := make([]*Decimal, len(buf)/size)
+	for i := range values {
+		v, err := getArrowDecimal(buf[i*size:(i+1)*size], precision, scale)
+		if err != nil {
+			return nil, errors.Newf("value %d: %s", i, err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// PutArrowDecimal128Column puts the column values as Arrow decimal128(precision,scale) into buf (len(values)*ArrowDecimal128Size bytes).
+// Nil values (nulls) are put as zero.
+func PutArrowDecimal128Column(buf []byte, values []*Decimal, precision, scale int) error {
+	if err := checkArrowDecimalType(precision, scale, _ArrowDecimal128MaxPrecision); err != nil {
+		return err
+	}
+	return putArrowDecimalColumn(buf, values, precision, scale, ArrowDecimal128Size)
+}
+
+// ArrowDecimal128Column returns the column values by decoding the Arrow decimal128(precision,scale) buffer buf.
+func ArrowDecimal128Column(buf []byte, precision, scale int) ([]*Decimal, error) {
+	if err := checkArrowDecimalType(precision, scale, _ArrowDecimal128MaxPrecision); err != nil {
+		return nil, err
+	}
+	return getArrowDecimalColumn(buf, precision, scale, ArrowDecimal128Size)
+}
+
+// PutArrowDecimal256Column puts the column values as Arrow decimal256(precision,scale) into buf (len(values)*ArrowDecimal256Size bytes).
+// Nil values (nulls) are put as zero.
+func PutArrowDecimal256Column(buf []byte, values []*Decimal, precision, scale int) error {
+	if err := checkArrowDecimalType(precision, scale, _ArrowDecimal256MaxPrecision); err != nil {
+		return err
+	}
+	return putArrowDecimalColumn(buf, values, precision, scale, ArrowDecimal256Size)
+}
+
+// ArrowDecimal256Column returns the column values by decoding the Arrow decimal256(precision,scale) buffer buf.
+func ArrowDecimal256Column(buf []byte, precision, scale int) ([]*Decimal, error) {
+	if err := checkArrowDecimalType(precision, scale, _ArrowDecimal256MaxPrecision); err != nil {
+		return nil, err
+	}
+	return getArrowDecimalColumn(buf, precision, scale, ArrowDecimal256Size)
+}
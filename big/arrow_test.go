@@ -0,0 +1,105 @@
+package big
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+func TestArrowDecimal(t *testing.T) {
+	// test PutArrowDecimal128 ArrowDecimal128
+	data := map[string][]byte{
+		"0":                     make([]byte, 16),
+		"1.23":                  append([]byte{0x7B}, make([]byte, 15)...),
+		"-0.01":                 bytes.Repeat([]byte{0xFF}, 16),
+		"-1.28":                 append([]byte{0x80, 0xFF}, bytes.Repeat([]byte{0xFF}, 14)...),
+		"184467440737095516.16": append([]byte{0, 0, 0, 0, 0, 0, 0, 0, 1}, make([]byte, 7)...), // 2**64
+	}
+	for k, v := range data {
+		b := make([]byte, ArrowDecimal128Size)
+		testing2.AssertEqual(t, PutArrowDecimal128(b, MustParseDecimal(k), 38, 2), nil)
+		testing2.AssertEqual(t, b, v)
+		d, err := ArrowDecimal128(v, 38, 2)
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.String(), k)
+	}
+
+	// test round trip
+	max38 := strings.Repeat("9", 38)
+	max76 := strings.Repeat("9", 76)
+	for k, v := range map[string][2]int{
+		max38:                     {38, 0},
+		"-" + max38:               {38, 0},
+		"0." + max38:              {38, 38},
+		"-9223372036854775808":    {38, 0},
+		"9223372036854775807":     {38, 0},
+		"-922337203685477580.8":   {38, 10},
+		"12345678901234567890.12": {38, 18},
+		"1e18":                    {38, 0},
+		"-1e-18":                  {38, 18},
+	} {
+		b := make([]byte, ArrowDecimal128Size)
+		testing2.AssertEqual(t, PutArrowDecimal128(b, MustParseDecimal(k), v[0], v[1]), nil)
+		d, err := ArrowDecimal128(b, v[0], v[1])
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.Cmp(MustParseDecimal(k)), 0)
+
+		b = make([]byte, ArrowDecimal256Size)
+		testing2.AssertEqual(t, PutArrowDecimal256(b, MustParseDecimal(k), v[0], v[1]), nil)
+		d, err = ArrowDecimal256(b, v[0], v[1])
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.Cmp(MustParseDecimal(k)), 0)
+	}
+	for _, v := range []string{max76, "-" + max76, "-1", "5"} {
+		b := make([]byte, ArrowDecimal256Size)
+		testing2.AssertEqual(t, PutArrowDecimal256(b, MustParseDecimal(v), 76, 0), nil)
+		d, err := ArrowDecimal256(b, 76, 0)
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.String(), v)
+	}
+
+	// test overflow and invalid arguments
+	b := make([]byte, ArrowDecimal256Size)
+	testing2.AssertNotEqual(t, PutArrowDecimal128(b, MustParseDecimal("1"+max38), 38, 0), nil)
+	testing2.AssertNotEqual(t, PutArrowDecimal128(b, MustParseDecimal("1000"), 5, 2), nil)
+	testing2.AssertNotEqual(t, PutArrowDecimal128(b, MustParseDecimal("-1000"), 5, 2), nil)
+	testing2.AssertNotEqual(t, PutArrowDecimal128(b, MustParseDecimal("0.001"), 5, 2), nil)
+	testing2.AssertNotEqual(t, PutArrowDecimal128(b, MustParseDecimal("1"), 39, 0), nil)
+	testing2.AssertNotEqual(t, PutArrowDecimal256(b, MustParseDecimal("1"), 77, 0), nil)
+	testing2.AssertNotEqual(t, PutArrowDecimal256(b, MustParseDecimal("1"+max76), 76, 0), nil)
+	_, err := ArrowDecimal128(append([]byte{0x10, 0x27}, make([]byte, 14)...), 4, 0) // 10000
+	testing2.AssertNotEqual(t, err, nil)
+
+	// test PutArrowDecimal128Column ArrowDecimal128Column
+	values := []*Decimal{MustParseDecimal("1.5"), nil, MustParseDecimal("-" + max38[:36]), MustParseDecimal("0.01")}
+	buf := make([]byte, len(values)*ArrowDecimal128Size)
+	testing2.AssertEqual(t, PutArrowDecimal128Column(buf, values, 38, 2), nil)
+	column, err := ArrowDecimal128Column(buf, 38, 2)
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, len(column), 4)
+	testing2.AssertEqual(t, column[0].String(), "1.5")
+	testing2.AssertEqual(t, column[1].String(), "0")
+	testing2.AssertEqual(t, column[2].String(), "-"+max38[:36])
+	testing2.AssertEqual(t, column[3].String(), "0.01")
+	testing2.AssertNotEqual(t, PutArrowDecimal128Column(buf[1:], values, 38, 2), nil)
+	_, err = ArrowDecimal128Column(buf[1:], 38, 2)
+	testing2.AssertNotEqual(t, err, nil)
+
+	// test PutArrowDecimal256Column ArrowDecimal256Column
+	buf = make([]byte, len(values)*ArrowDecimal256Size)
+	testing2.AssertEqual(t, PutArrowDecimal256Column(buf, values, 40, 2), nil)
+	column, err = ArrowDecimal256Column(buf, 40, 2)
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, column[2].String(), "-"+max38[:36])
+	testing2.AssertNotEqual(t, PutArrowDecimal256Column(buf, []*Decimal{MustParseDecimal("0.001")}, 40, 2), nil)
+
+	// test allocations (no big.Int for values fitting in int64)
+	b = append([]byte{0x7B}, make([]byte, 31)...)
+	allocs := testing.AllocsPerRun(100, func() {
+		ArrowDecimal128(b[:ArrowDecimal128Size], 38, 2)
+		ArrowDecimal256(b, 40, 2)
+	})
+	testing2.AssertEqual(t, allocs, float64(2)) // the decimals
+}
@@ -0,0 +1,121 @@
+package big
+
+import (
+	"math/big"
+
+	"github.com/golang-plus/errors"
+)
+
+// Avro and Parquet store the decimal logical type as the big-endian two's-complement bytes of the unscaled integer,
+// with precision and scale declared by the schema (as variable-length "bytes" or as "fixed" with a declared size).
+
+func checkAvroDecimalType(precision, scale int) error {
+	if precision < 1 || scale < 0 || scale > precision {
+		return errors.Newf("decimal(%d,%d) is invalid", precision, scale)
+	}
+	return nil
+}
+
+// avroUnscaled returns the unscaled integer of d rescaled to scale.
+func avroUnscaled(d *Decimal, precision, scale int) (*big.Int, error) {
+	if err := checkAvroDecimalType(precision, scale); err != nil {
+		return nil, err
+	}
+	unscaled, exact, fits := d.scaledIntegerWithin(precision, scale)
+	if !exact {
+		return nil, errors.Newf("decimal has more than %d decimal digits", scale)
+	}
+	if !fits {
+		return nil, errors.Newf("decimal overflows decimal(%d,%d)", precision, scale)
+	}
+	return unscaled, nil
+}
+
+// twosComplement returns the minimal big-endian two's-complement bytes of x.
+func twosComplement(x *big.Int) []byte {
+	if x.Sign() >= 0 {
+		b := x.Bytes()
+		if len(b) == 0 || b[0]&0x80 != 0 {
+			b = append([]byte{0}, b...)
+		}
+		return b
+	}
+	// -m-1 is ^m in two's complement
+	b := new(big.Int).Sub(new(big.Int).Neg(x), big.NewInt(1)).Bytes()
+	for i := range b {
+		b[i] = ^b[i]
+	}
+	if len(b) == 0 || b[0]&0x80 == 0 {
+		b = append([]byte{0xFF}, b...)
+	}
+	return b
+}
+
+// AppendAvroDecimal appends the Avro/Parquet "bytes" decimal representation of d with given precision and scale to dst and returns the extended buffer.
+// An error is returned if d has more decimal digits than scale or more digits than precision (it is not rounded).
+func AppendAvroDecimal(dst []byte, d *Decimal, precision, scale int) ([]byte, error) {
+	unscaled, err := avroUnscaled(d, precision, scale)
+	if err != nil {
+		return nil, err
+	}
+	return append(dst, twosComplement(unscaled)...), nil
+}
+
+// EncodeAvroDecimal returns the Avro/Parquet "bytes" decimal representation of d with given precision and scale.
+func EncodeAvroDecimal(d *Decimal, precision, scale int) ([]byte, error) {
+	return AppendAvroDecimal(nil, d, precision, scale)
+}
+
+// AppendAvroDecimalFixed appends the Avro/Parquet "fixed" decimal representation (sign-extended to size bytes) of d with given precision and scale to dst and returns the extended buffer.
+func AppendAvroDecimalFixed(dst []byte, d *Decimal, precision, scale, size int) ([]byte, error) {
+	unscaled, err := avroUnscaled(d, precision, scale)
+	if err != nil {
+		return nil, err
+	}
+	b := twosComplement(unscaled)
+	if len(b) > size {
+		return nil, errors.Newf("decimal overflows fixed[%d]", size)
+	}
+	pad := byte(0)
+	if unscaled.Sign() < 0 {
+		pad = 0xFF
+	}
+	for i := len(b); i < size; i++ {
+		dst = append(dst, pad)
+	}
+	return append(dst, b...), nil
+}
+
+// EncodeAvroDecimalFixed returns the Avro/Parquet "fixed" decimal representation (sign-extended to size bytes) of d with given precision and scale.
+func EncodeAvroDecimalFixed(d *Decimal, precision, scale, size int) ([]byte, error) {
+	return AppendAvroDecimalFixed(nil, d, precision, scale, size)
+}
+
+// DecodeAvroDecimal returns a new decimal by decoding the Avro/Parquet decimal representation ("bytes" or "fixed") with given precision and scale.
+func DecodeAvroDecimal(data []byte, precision, scale int) (*Decimal, error) {
+	if err := checkAvroDecimalType(precision, scale); err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("decimal data is empty")
+	}
+	d := new(Decimal)
+	d.ensureInitialized()
+	if data[0]&0x80 == 0 {
+		d.integer.SetBytes(data)
+	} else { // negative: ^m is -m-1
+		b := make([]byte, len(data))
+		for i := range data {
+			b[i] = ^data[i]
+		}
+		d.integer.SetBytes(b)
+		d.integer.Add(d.integer, big.NewInt(1))
+		d.integer.Neg(d.integer)
+	}
+	if d.integer.CmpAbs(pow10(precision)) >= 0 {
+		return nil, errors.Newf("decimal data overflows decimal(%d,%d)", precision, scale)
+	}
+	d.exponent = scale * -1
+	d.trimFraction()
+	return d, nil
+}
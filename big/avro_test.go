@@ -0,0 +1,84 @@
+package big
+
+import (
+	"testing"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+func TestAvroDecimal(t *testing.T) {
+	// test EncodeAvroDecimal DecodeAvroDecimal
+	data := map[string][3]interface{}{ // precision, scale, bytes
+		"0":     {5, 0, []byte{0x00}},
+		"1.23":  {5, 2, []byte{0x7B}},
+		"-1.23": {5, 2, []byte{0x85}},
+		"128":   {5, 0, []byte{0x00, 0x80}},
+		"-128":  {5, 0, []byte{0x80}},
+		"-129":  {5, 0, []byte{0xFF, 0x7F}},
+		"1.2":   {5, 3, []byte{0x04, 0xB0}},
+		"-1":    {5, 2, []byte{0x9C}},
+	}
+	for k, v := range data {
+		b, err := EncodeAvroDecimal(MustParseDecimal(k), v[0].(int), v[1].(int))
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, b, v[2])
+		d, err := DecodeAvroDecimal(v[2].([]byte), v[0].(int), v[1].(int))
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.String(), k)
+	}
+
+	// test EncodeAvroDecimalFixed
+	b, err := EncodeAvroDecimalFixed(MustParseDecimal("-0.01"), 9, 2, 4)
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, b, []byte{0xFF, 0xFF, 0xFF, 0xFF})
+	b, err = EncodeAvroDecimalFixed(MustParseDecimal("1234567.89"), 9, 2, 4)
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, b, []byte{0x07, 0x5B, 0xCD, 0x15})
+	d, err := DecodeAvroDecimal(b, 9, 2)
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, d.String(), "1234567.89")
+	_, err = EncodeAvroDecimalFixed(MustParseDecimal("12345678901234567890"), 38, 0, 8)
+	testing2.AssertNotEqual(t, err, nil)
+
+	// test round trip
+	for k, v := range map[string]int{
+		"12345678901234567890123456789012345678":   0,
+		"-12345678901234567890123456789012345678":  0,
+		"0.00000000000000000000000000000000000001": 38,
+		"-99999999999999999999.999999999999999999": 18,
+	} {
+		b, err := EncodeAvroDecimalFixed(MustParseDecimal(k), 38, v, 16)
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, len(b), 16)
+		d, err := DecodeAvroDecimal(b, 38, v)
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.String(), k)
+	}
+	for _, v := range []string{"12345678901234567890.123", "-0.001", "1e10"} {
+		b, err := EncodeAvroDecimal(MustParseDecimal(v), 38, 10)
+		testing2.AssertEqual(t, err, nil)
+		d, err := DecodeAvroDecimal(b, 38, 10)
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.Cmp(MustParseDecimal(v)), 0)
+	}
+
+	// test overflow and invalid arguments
+	for k, v := range map[string][2]int{
+		"1000":   {5, 2},
+		"-1000":  {5, 2},
+		"0.001":  {5, 2},
+		"0.5":    {1, 0},
+		"123.45": {4, 1},
+		"1":      {0, 0},
+		"2":      {5, 6},
+	} {
+		_, err := EncodeAvroDecimal(MustParseDecimal(k), v[0], v[1])
+		testing2.AssertNotEqual(t, err, nil)
+	}
+	_, err = DecodeAvroDecimal(nil, 5, 2)
+	testing2.AssertNotEqual(t, err, nil)
+	_, err = DecodeAvroDecimal([]byte{0x27, 0x10}, 4, 0) // 10000
+	testing2.AssertNotEqual(t, err, nil)
+	_, err = DecodeAvroDecimal([]byte{0xD8, 0xF0}, 4, 0) // -10000
+	testing2.AssertNotEqual(t, err, nil)
+}
@@ -0,0 +1,232 @@
+package big
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/golang-plus/errors"
+)
+
+// CBOR major types and tags (RFC 8949).
+const (
+	_CBORUnsigned = 0
+	_CBORNegative = 1
+	_CBORBytes    = 2
+	_CBORArray    = 4
+	_CBORTag      = 6
+
+	_CBORTagPositiveBignum  = 2
+	_CBORTagNegativeBignum  = 3
+	_CBORTagDecimalFraction = 4
+)
+
+var (
+	// MsgpackExtType is the MessagePack extension type of decimals,
+	// the extension data is the compact format of the decimal (see AppendCompact).
+	MsgpackExtType = int8(1)
+)
+
+func appendCBORHead(dst []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(dst, major|byte(n))
+	case n <= 0xFF:
+		return append(dst, major|24, byte(n))
+	case n <= 0xFFFF:
+		return binary.BigEndian.AppendUint16(append(dst, major|25), uint16(n))
+	case n <= 0xFFFFFFFF:
+		return binary.BigEndian.AppendUint32(append(dst, major|26), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(dst, major|27), n)
+}
+
+// readCBORHead returns the major type, the argument and the size of the head at the beginning of data.
+func readCBORHead(data []byte) (byte, uint64, int, error) {
+	if len(data) == 0 {
+		return 0, 0, 0, errors.New("CBOR data is truncated")
+	}
+	major, info := data[0]>>5, data[0]&0x1F
+	if info < 24 {
+		return major, uint64(info), 1, nil
+	}
+	if info > 27 {
+		return 0, 0, 0, errors.Newf("CBOR additional information %d is unsupported", info)
+	}
+	size := 1 << (info - 24)
+	if len(data) < 1+size {
+		return 0, 0, 0, errors.New("CBOR data is truncated")
+	}
+	var n uint64
+	for _, b := range data[1 : 1+size] {
+		n = n<<8 | uint64(b)
+	}
+	return major, n, 1 + size, nil
+}
+
+// appendCBORInt appends the CBOR integer (or bignum) x to dst.
+func appendCBORInt(dst []byte, x *big.Int) []byte {
+	if x.Sign() >= 0 {
+		if x.IsUint64() {
+			return appendCBORHead(dst, _CBORUnsigned, x.Uint64())
+		}
+		b := x.Bytes()
+		dst = appendCBORHead(dst, _CBORTag, _CBORTagPositiveBignum)
+		return append(appendCBORHead(dst, _CBORBytes, uint64(len(b))), b...)
+	}
+	n := new(big.Int).Not(x) // -1-x
+	if n.IsUint64() {
+		return appendCBORHead(dst, _CBORNegative, n.Uint64())
+	}
+	b := n.Bytes()
+	dst = appendCBORHead(dst, _CBORTag, _CBORTagNegativeBignum)
+	return append(appendCBORHead(dst, _CBORBytes, uint64(len(b))), b...)
+}
+
+// readCBORInt sets z to the CBOR integer (or bignum if allowed) at the beginning of data and returns the size read.
+func readCBORInt(z *big.Int, data []byte, bignum bool) (int, error) {
+	major, arg, n, err := readCBORHead(data)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case major == _CBORUnsigned:
+		z.SetUint64(arg)
+		return n, nil
+	case major == _CBORNegative:
+		z.Not(z.SetUint64(arg))
+		return n, nil
+	case major == _CBORTag && bignum && (arg == _CBORTagPositiveBignum || arg == _CBORTagNegativeBignum):
+		major, size, m, err := readCBORHead(data[n:])
+		if err != nil {
+			return 0, err
+		}
+		if major != _CBORBytes {
+			return 0, errors.New("CBOR bignum must be a byte string")
+		}
+		n += m
+		if size > uint64(len(data)-n) {
+			return 0, errors.New("CBOR data is truncated")
+		}
+		z.SetBytes(data[n : n+int(size)])
+		if arg == _CBORTagNegativeBignum {
+			z.Not(z)
+		}
+		return n + int(size), nil
+	}
+	return 0, errors.New("CBOR data item is not an integer")
+}
+
+// MarshalCBOR returns the CBOR decimal fraction (tag 4: [exponent, mantissa]) of d, the mantissa is a bignum if it overflows 64 bits.
+func (d Decimal) MarshalCBOR() ([]byte, error) {
+	dst := appendCBORHead(nil, _CBORTag, _CBORTagDecimalFraction)
+	dst = appendCBORHead(dst, _CBORArray, 2)
+	dst = appendCBORInt(dst, big.NewInt(int64(d.exponent)))
+	return appendCBORInt(dst, d.coefficient(new(big.Int))), nil
+}
+
+// UnmarshalCBOR sets d to the value of the CBOR decimal fraction (tag 4) data.
+// The limits of DecimalLimits are enforced.
+func (d *Decimal) UnmarshalCBOR(data []byte) error {
+	major, arg, n, err := readCBORHead(data)
+	if err != nil {
+		return err
+	}
+	if major != _CBORTag || arg != _CBORTagDecimalFraction {
+		return errors.New("CBOR data item is not a decimal fraction (tag 4)")
+	}
+	major, arg, m, err := readCBORHead(data[n:])
+	if err != nil {
+		return err
+	}
+	if major != _CBORArray || arg != 2 {
+		return errors.New("CBOR decimal fraction must be an array of 2 integers")
+	}
+	n += m
+	exp := new(big.Int)
+	m, err = readCBORInt(exp, data[n:], false)
+	if err != nil {
+		return err
+	}
+	if !exp.IsInt64() || int64(int(exp.Int64())) != exp.Int64() {
+		return errors.Newf("CBOR decimal fraction exponent %s is out of range", exp)
+	}
+	n += m
+	mantissa := new(big.Int)
+	m, err = readCBORInt(mantissa, data[n:], true)
+	if err != nil {
+		return err
+	}
+	if n+m != len(data) {
+		return errors.New("unexpected data after CBOR decimal fraction")
+	}
+	if err := DecimalLimits.check(mantissa, int(exp.Int64())); err != nil {
+		return err
+	}
+	d.integer, d.inline = mantissa, false
+	d.exponent = int(exp.Int64())
+	d.demote()
+	return nil
+}
+
+// MarshalMsgpack returns the MessagePack extension (type MsgpackExtType) of d.
+func (d Decimal) MarshalMsgpack() ([]byte, error) {
+	payload := d.AppendCompact(nil)
+	var dst []byte
+	switch size := len(payload); {
+	case size == 1 || size == 2 || size == 4 || size == 8 || size == 16: // fixext
+		dst = append(dst, [17]byte{1: 0xD4, 2: 0xD5, 4: 0xD6, 8: 0xD7, 16: 0xD8}[size])
+	case size <= 0xFF:
+		dst = append(dst, 0xC7, byte(size))
+	case size <= 0xFFFF:
+		dst = binary.BigEndian.AppendUint16(append(dst, 0xC8), uint16(size))
+	default:
+		dst = binary.BigEndian.AppendUint32(append(dst, 0xC9), uint32(size))
+	}
+	dst = append(dst, byte(MsgpackExtType))
+	return append(dst, payload...), nil
+}
+
+// UnmarshalMsgpack sets d to the value of the MessagePack extension (type MsgpackExtType) data.
+// The limits of DecimalLimits are enforced (see DecodeCompact).
+func (d *Decimal) UnmarshalMsgpack(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("MessagePack data is empty")
+	}
+	var size, n int
+	switch code := data[0]; code {
+	case 0xD4, 0xD5, 0xD6, 0xD7, 0xD8:
+		size, n = 1<<(code-0xD4), 1
+	case 0xC7:
+		if len(data) >= 2 {
+			size, n = int(data[1]), 2
+		}
+	case 0xC8:
+		if len(data) >= 3 {
+			size, n = int(binary.BigEndian.Uint16(data[1:])), 3
+		}
+	case 0xC9:
+		if len(data) >= 5 {
+			size, n = int(binary.BigEndian.Uint32(data[1:])), 5
+		}
+	default:
+		return errors.Newf("MessagePack format 0x%02X is not an extension", code)
+	}
+	if n == 0 || len(data) != n+1+size {
+		return errors.New("MessagePack extension size is invalid")
+	}
+	if typ := int8(data[n]); typ != MsgpackExtType {
+		return errors.Newf("MessagePack extension type %d is not decimal", typ)
+	}
+	payload := data[n+1:]
+	var z Decimal // d is unchanged on error
+	m, err := z.DecodeCompact(payload)
+	if err != nil {
+		return err
+	}
+	if m != len(payload) {
+		return errors.New("unexpected data after MessagePack decimal")
+	}
+	d.Copy(&z)
+	return nil
+}
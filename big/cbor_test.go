@@ -0,0 +1,73 @@
+package big
+
+import (
+	"strings"
+	"testing"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+func TestCBOR(t *testing.T) {
+	// test MarshalCBOR
+	data := map[string][]byte{
+		"273.15":                {0xC4, 0x82, 0x21, 0x19, 0x6A, 0xB3},
+		"-1.5":                  {0xC4, 0x82, 0x20, 0x2E},
+		"0":                     {0xC4, 0x82, 0x00, 0x00},
+		"18446744073709551616":  {0xC4, 0x82, 0x00, 0xC2, 0x49, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		"-18446744073709551617": {0xC4, 0x82, 0x00, 0xC3, 0x49, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		"1e300":                 {0xC4, 0x82, 0x19, 0x01, 0x2C, 0x01},
+	}
+	for k, v := range data {
+		b, err := MustParseDecimal(k).MarshalCBOR()
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, b, v)
+		d := new(Decimal)
+		testing2.AssertEqual(t, d.UnmarshalCBOR(v), nil)
+		testing2.AssertEqual(t, d.String(), MustParseDecimal(k).String())
+	}
+
+	// test UnmarshalCBOR
+	d := new(Decimal)
+	testing2.AssertEqual(t, d.UnmarshalCBOR([]byte{0xC4, 0x82, 0x38, 0x1F, 0x1B, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01}), nil) // long forms
+	testing2.AssertEqual(t, d.String(), "0.00000000000000000000000000000001")
+	for _, v := range [][]byte{
+		nil,
+		{0xC5, 0x82, 0x21, 0x19, 0x6A, 0xB3}, // tag 5
+		{0xC4, 0x83, 0x21, 0x19, 0x6A, 0xB3}, // array of 3
+		{0xC4, 0x82, 0x21, 0x19, 0x6A},       // truncated
+		{0xC4, 0x82, 0xC2, 0x41, 0x01, 0x01}, // bignum exponent
+		{0xC4, 0x82, 0x21, 0x61, 0x31},       // text mantissa
+		{0xC4, 0x82, 0x21, 0x01, 0x01},       // trailing data
+		{0xC4, 0x82, 0x21, 0xC2, 0x45, 0x01}, // truncated bignum
+		{0xC4, 0x82, 0x1B, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01}, // exponent out of range
+	} {
+		testing2.AssertNotEqual(t, new(Decimal).UnmarshalCBOR(v), nil)
+	}
+
+	// test MarshalMsgpack UnmarshalMsgpack
+	b, err := MustParseDecimal("19.99").MarshalMsgpack()
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, b, []byte{0xC7, 0x03, 0x01, 0x03, 0xBC, 0x3E})
+	b, err = MustParseDecimal("-1").MarshalMsgpack()
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, b, []byte{0xD5, 0x01, 0x00, 0x06})
+	for _, v := range []string{"0", "-1", "19.99", "123456789012345678901234567890.123456789", "1e-300"} {
+		b, err := MustParseDecimal(v).MarshalMsgpack()
+		testing2.AssertEqual(t, err, nil)
+		d := new(Decimal)
+		testing2.AssertEqual(t, d.UnmarshalMsgpack(b), nil)
+		testing2.AssertEqual(t, d.String(), MustParseDecimal(v).String())
+	}
+	large := MustParseDecimal(strings.Repeat("9", 700) + "e-3")
+	b, err = large.MarshalMsgpack()
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, b[0], byte(0xC8)) // ext 16
+	testing2.AssertEqual(t, d.UnmarshalMsgpack(b), nil)
+	testing2.AssertEqual(t, d.Cmp(large), 0)
+	for _, v := range [][]byte{nil, {0xC0}, {0xD5, 0x02, 0x00, 0x06}, {0xD6, 0x01, 0x00, 0x06}, {0xC7, 0x02, 0x01, 0x00, 0x06, 0x00}, {0xC7}} {
+		testing2.AssertNotEqual(t, new(Decimal).UnmarshalMsgpack(v), nil)
+	}
+	d = MustParseDecimal("1.5")
+	testing2.AssertNotEqual(t, d.UnmarshalMsgpack([]byte{0xC7, 0x04, 0x01, 0x03, 0xBC, 0x3E, 0x00}), nil) // trailing byte
+	testing2.AssertEqual(t, d.String(), "1.5")
+}
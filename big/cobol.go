@@ -0,0 +1,251 @@
+package big
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/golang-plus/errors"
+)
+
+// Picture describes a COBOL numeric picture clause.
+// e.g. PIC S9(7)V99 is Picture{Digits: 9, Scale: 2, Signed: true}.
+type Picture struct {
+	Digits int  // total number of digits
+	Scale  int  // implied decimal digits (after V)
+	Signed bool // has sign (S)
+}
+
+func (p Picture) check() error {
+	if p.Digits < 1 || p.Scale < 0 || p.Scale > p.Digits {
+		return errors.Newf("picture %s is invalid", p)
+	}
+	return nil
+}
+
+// String returns the picture clause of p (e.g. "S9(7)V9(2)").
+func (p Picture) String() string {
+	var buf strings.Builder
+	if p.Signed {
+		buf.WriteString("S")
+	}
+	if p.Digits > p.Scale {
+		buf.WriteString("9(" + strconv.Itoa(p.Digits-p.Scale) + ")")
+	}
+	if p.Scale > 0 {
+		buf.WriteString("V9(" + strconv.Itoa(p.Scale) + ")")
+	}
+	return buf.String()
+}
+
+// digits returns the digits of d (padded to p.Digits) and a boolean indicating whether d is negative.
+func (p Picture) digits(d *Decimal) (string, bool, error) {
+	if err := p.check(); err != nil {
+		return "", false, err
+	}
+	scaled, exact, fits := d.scaledIntegerWithin(p.Digits, p.Scale)
+	if !exact {
+		return "", false, errors.Newf("decimal has more than %d decimal digits", p.Scale)
+	}
+	if !fits {
+		return "", false, errors.Newf("decimal overflows picture %s", p)
+	}
+	negative := scaled.Sign() < 0
+	if negative && !p.Signed {
+		return "", false, errors.Newf("decimal is negative but picture %s is unsigned", p)
+	}
+	str := scaled.Abs(scaled).String()
+	return strings.Repeat("0", p.Digits-len(str)) + str, negative, nil
+}
+
+// decimal returns the decimal of digits with the implied scale of p.
+func (p Picture) decimal(digits []byte, negative bool) *Decimal {
+	d := new(Decimal)
+	d.ensureInitialized()
+	str := string(digits)
+	if negative {
+		str = "-" + str
+	}
+	d.integer.SetString(str, 10)
+	d.exponent = p.Scale * -1
+	d.trimFraction()
+	return d
+}
+
+// PackedDecimalSize returns the number of bytes of a packed decimal (COMP-3) with given digits.
+func PackedDecimalSize(digits int) int {
+	return digits/2 + 1
+}
+
+// AppendPackedDecimal appends the packed decimal (COMP-3) representation of d with picture p to dst and returns the extended buffer.
+// Two digits are packed per byte followed by the sign nibble (C positive, D negative, F unsigned).
+// An error is returned if d does not fit the picture (it is not rounded).
+func AppendPackedDecimal(dst []byte, d *Decimal, p Picture) ([]byte, error) {
+	digits, negative, err := p.digits(d)
+	if err != nil {
+		return nil, err
+	}
+	if len(digits)%2 == 0 {
+		digits = "0" + digits
+	}
+	sign := byte(0x0F)
+	if p.Signed {
+		sign = 0x0C
+		if negative {
+			sign = 0x0D
+		}
+	}
+	for i := 0; i+1 < len(digits); i += 2 {
+		dst = append(dst, (digits[i]-'0')<<4|(digits[i+1]-'0'))
+	}
+	return append(dst, (digits[len(digits)-1]-'0')<<4|sign), nil
+}
+
+// EncodePackedDecimal returns the packed decimal (COMP-3) representation of d with picture p.
+func EncodePackedDecimal(d *Decimal, p Picture) ([]byte, error) {
+	return AppendPackedDecimal(nil, d, p)
+}
+
+// DecodePackedDecimal returns a new decimal by decoding the packed decimal (COMP-3) data with picture p.
+// Sign nibbles A, C, E and F are positive, B and D are negative.
+func DecodePackedDecimal(data []byte, p Picture) (*Decimal, error) {
+	if err := p.check(); err != nil {
+		return nil, err
+	}
+	if len(data) != PackedDecimalSize(p.Digits) {
+		return nil, errors.Newf("packed decimal of picture %s must be %d bytes", p, PackedDecimalSize(p.Digits))
+	}
+	digits := make([]byte, 0, len(data)*2)
+	for i, b := range data {
+		digits = append(digits, b>>4)
+		if i < len(data)-1 {
+			digits = append(digits, b&0x0F)
+		}
+	}
+	for i := range digits {
+		if digits[i] > 9 {
+			return nil, errors.Newf("packed decimal digit 0x%X is invalid", digits[i])
+		}
+		digits[i] += '0'
+	}
+	if p.Digits%2 == 0 {
+		if digits[0] != '0' {
+			return nil, errors.New("packed decimal padding nibble must be zero")
+		}
+		digits = digits[1:]
+	}
+	negative := false
+	switch sign := data[len(data)-1] & 0x0F; sign {
+	case 0x0A, 0x0C, 0x0E, 0x0F:
+	case 0x0B, 0x0D:
+		negative = true
+	default:
+		return nil, errors.Newf("packed decimal sign 0x%X is invalid", sign)
+	}
+	if negative && !p.Signed {
+		return nil, errors.Newf("packed decimal is negative but picture %s is unsigned", p)
+	}
+	return p.decimal(digits, negative), nil
+}
+
+// ZonedEncoding represents the character encoding of a zoned decimal.
+type ZonedEncoding int
+
+const (
+	// ZonedEBCDIC is the EBCDIC zoned decimal, digits are 0xF0-0xF9 and the sign is the zone (C positive, D negative, F unsigned) of the last byte.
+	ZonedEBCDIC ZonedEncoding = iota
+	// ZonedASCII is the ASCII zoned decimal, digits are '0'-'9' and the sign is overpunched on the last byte
+	// ('{' 'A'-'I' positive, '}' 'J'-'R' negative, 'p'-'y' negative is accepted when decoding).
+	ZonedASCII
+)
+
+// AppendZonedDecimal appends the zoned decimal representation of d with picture p and encoding enc to dst and returns the extended buffer.
+// An error is returned if d does not fit the picture (it is not rounded).
+func AppendZonedDecimal(dst []byte, d *Decimal, p Picture, enc ZonedEncoding) ([]byte, error) {
+	digits, negative, err := p.digits(d)
+	if err != nil {
+		return nil, err
+	}
+	start := len(dst)
+	last := digits[len(digits)-1] - '0'
+	switch enc {
+	case ZonedEBCDIC:
+		for i := 0; i < len(digits); i++ {
+			dst = append(dst, 0xF0|(digits[i]-'0'))
+		}
+		if p.Signed {
+			dst[len(dst)-1] = 0xC0 | last
+			if negative {
+				dst[len(dst)-1] = 0xD0 | last
+			}
+		}
+	case ZonedASCII:
+		dst = append(dst, digits...)
+		if p.Signed {
+			dst[len(dst)-1] = "{ABCDEFGHI"[last]
+			if negative {
+				dst[len(dst)-1] = "}JKLMNOPQR"[last]
+			}
+		}
+	default:
+		return dst[:start], errors.Newf("zoned encoding %d is invalid", enc)
+	}
+	return dst, nil
+}
+
+// EncodeZonedDecimal returns the zoned decimal representation of d with picture p and encoding enc.
+func EncodeZonedDecimal(d *Decimal, p Picture, enc ZonedEncoding) ([]byte, error) {
+	return AppendZonedDecimal(nil, d, p, enc)
+}
+
+// DecodeZonedDecimal returns a new decimal by decoding the zoned decimal data with picture p and encoding enc.
+func DecodeZonedDecimal(data []byte, p Picture, enc ZonedEncoding) (*Decimal, error) {
+	if err := p.check(); err != nil {
+		return nil, err
+	}
+	if len(data) != p.Digits {
+		return nil, errors.Newf("zoned decimal of picture %s must be %d bytes", p, p.Digits)
+	}
+	digits := make([]byte, len(data))
+	negative := false
+	for i, b := range data {
+		isLast := i == len(data)-1
+		switch enc {
+		case ZonedEBCDIC:
+			zone, digit := b>>4, b&0x0F
+			switch {
+			case digit > 9:
+				return nil, errors.Newf("zoned decimal byte 0x%02X is invalid", b)
+			case zone == 0x0F:
+			case isLast && (zone == 0x0A || zone == 0x0C || zone == 0x0E):
+			case isLast && (zone == 0x0B || zone == 0x0D):
+				negative = true
+			default:
+				return nil, errors.Newf("zoned decimal byte 0x%02X is invalid", b)
+			}
+			digits[i] = '0' + digit
+		case ZonedASCII:
+			switch {
+			case b >= '0' && b <= '9':
+				digits[i] = b
+			case isLast && b == '{':
+				digits[i] = '0'
+			case isLast && b >= 'A' && b <= 'I':
+				digits[i] = '1' + (b - 'A')
+			case isLast && b == '}':
+				digits[i], negative = '0', true
+			case isLast && b >= 'J' && b <= 'R':
+				digits[i], negative = '1'+(b-'J'), true
+			case isLast && b >= 'p' && b <= 'y':
+				digits[i], negative = '0'+(b-'p'), true
+			default:
+				return nil, errors.Newf("zoned decimal byte %q is invalid", b)
+			}
+		default:
+			return nil, errors.Newf("zoned encoding %d is invalid", enc)
+		}
+	}
+	if negative && !p.Signed {
+		return nil, errors.Newf("zoned decimal is negative but picture %s is unsigned", p)
+	}
+	return p.decimal(digits, negative), nil
+}
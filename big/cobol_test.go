@@ -0,0 +1,105 @@
+package big
+
+import (
+	"testing"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+func TestCOBOL(t *testing.T) {
+	// test Picture
+	testing2.AssertEqual(t, Picture{Digits: 9, Scale: 2, Signed: true}.String(), "S9(7)V9(2)")
+	testing2.AssertEqual(t, Picture{Digits: 3}.String(), "9(3)")
+	testing2.AssertEqual(t, Picture{Digits: 2, Scale: 2}.String(), "V9(2)")
+	testing2.AssertEqual(t, PackedDecimalSize(9), 5)
+	testing2.AssertEqual(t, PackedDecimalSize(4), 3)
+
+	// test EncodePackedDecimal DecodePackedDecimal
+	s9v99 := Picture{Digits: 9, Scale: 2, Signed: true}
+	data := map[string][]byte{
+		"1234567.89": {0x12, 0x34, 0x56, 0x78, 0x9C},
+		"-12.3":      {0x00, 0x00, 0x01, 0x23, 0x0D},
+		"0":          {0x00, 0x00, 0x00, 0x00, 0x0C},
+	}
+	for k, v := range data {
+		b, err := EncodePackedDecimal(MustParseDecimal(k), s9v99)
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, b, v)
+		d, err := DecodePackedDecimal(v, s9v99)
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.String(), k)
+	}
+	b, err := EncodePackedDecimal(MustParseDecimal("123.4"), Picture{Digits: 4, Scale: 1})
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, b, []byte{0x01, 0x23, 0x4F})
+	d, err := DecodePackedDecimal([]byte{0x01, 0x23, 0x4B}, Picture{Digits: 4, Scale: 1, Signed: true})
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, d.String(), "-123.4")
+	for _, v := range [][]byte{
+		{0x12, 0x34, 0x56, 0x78},       // too short
+		{0x12, 0x34, 0x56, 0x7A, 0x9C}, // invalid digit
+		{0x12, 0x34, 0x56, 0x78, 0x99}, // invalid sign
+	} {
+		_, err := DecodePackedDecimal(v, s9v99)
+		testing2.AssertNotEqual(t, err, nil)
+	}
+	_, err = DecodePackedDecimal([]byte{0x11, 0x23, 0x4C}, Picture{Digits: 4, Scale: 1}) // padding nibble
+	testing2.AssertNotEqual(t, err, nil)
+	_, err = DecodePackedDecimal([]byte{0x01, 0x23, 0x4D}, Picture{Digits: 4, Scale: 1}) // negative unsigned
+	testing2.AssertNotEqual(t, err, nil)
+
+	// test EncodeZonedDecimal DecodeZonedDecimal
+	s9v9 := Picture{Digits: 4, Scale: 1, Signed: true}
+	data2 := map[string][2][]byte{ // EBCDIC, ASCII
+		"123.4":  {{0xF1, 0xF2, 0xF3, 0xC4}, []byte("123D")},
+		"-123.4": {{0xF1, 0xF2, 0xF3, 0xD4}, []byte("123M")},
+		"-12":    {{0xF0, 0xF1, 0xF2, 0xD0}, []byte("012}")},
+		"0.1":    {{0xF0, 0xF0, 0xF0, 0xC1}, []byte("000A")},
+		"0":      {{0xF0, 0xF0, 0xF0, 0xC0}, []byte("000{")},
+	}
+	for k, v := range data2 {
+		for i, enc := range []ZonedEncoding{ZonedEBCDIC, ZonedASCII} {
+			b, err := EncodeZonedDecimal(MustParseDecimal(k), s9v9, enc)
+			testing2.AssertEqual(t, err, nil)
+			testing2.AssertEqual(t, b, v[i])
+			d, err := DecodeZonedDecimal(v[i], s9v9, enc)
+			testing2.AssertEqual(t, err, nil)
+			testing2.AssertEqual(t, d.String(), k)
+		}
+	}
+	b, err = EncodeZonedDecimal(MustParseDecimal("42"), Picture{Digits: 3}, ZonedASCII)
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, string(b), "042")
+	b, err = EncodeZonedDecimal(MustParseDecimal("42"), Picture{Digits: 3}, ZonedEBCDIC)
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, b, []byte{0xF0, 0xF4, 0xF2})
+	d, err = DecodeZonedDecimal([]byte("12r"), Picture{Digits: 3, Signed: true}, ZonedASCII)
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, d.String(), "-122")
+	for _, v := range [][]byte{[]byte("1D3D"), []byte("12 4"), []byte("123"), []byte("123Z")} {
+		_, err := DecodeZonedDecimal(v, s9v9, ZonedASCII)
+		testing2.AssertNotEqual(t, err, nil)
+	}
+	for _, v := range [][]byte{{0xF1, 0xC2, 0xF3, 0xC4}, {0xF1, 0xF2, 0xF3, 0xCA}, {0xF1, 0xF2, 0xF3, 0x14}} {
+		_, err := DecodeZonedDecimal(v, s9v9, ZonedEBCDIC)
+		testing2.AssertNotEqual(t, err, nil)
+	}
+	_, err = DecodeZonedDecimal([]byte("12}"), Picture{Digits: 3}, ZonedASCII)
+	testing2.AssertNotEqual(t, err, nil)
+
+	// test overflow and invalid arguments
+	for k, v := range map[string]Picture{
+		"12345.6": s9v9,
+		"1.23":    s9v9,
+		"-1":      {Digits: 3},
+		"1":       {Digits: 0},
+		"2":       {Digits: 2, Scale: 3},
+	} {
+		_, err := EncodePackedDecimal(MustParseDecimal(k), v)
+		testing2.AssertNotEqual(t, err, nil)
+		_, err = EncodeZonedDecimal(MustParseDecimal(k), v, ZonedEBCDIC)
+		testing2.AssertNotEqual(t, err, nil)
+	}
+	_, err = EncodeZonedDecimal(MustParseDecimal("1"), s9v9, ZonedEncoding(9))
+	testing2.AssertNotEqual(t, err, nil)
+}
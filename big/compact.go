@@ -0,0 +1,91 @@
+package big
+
+import (
+	"encoding/binary"
+
+	"github.com/golang-plus/errors"
+)
+
+// The compact format of a decimal is: the exponent as a zig-zag varint followed by a uvarint header h of the coefficient.
+// If the lowest bit of h is 0, the absolute coefficient is h>>2 (fast path for coefficients less than 2**62),
+// otherwise h>>2 big-endian bytes of the absolute coefficient follow. The second lowest bit of h is the sign.
+const (
+	_CompactLarge    = 1
+	_CompactNegative = 2
+	_CompactMaxSmall = 1<<62 - 1
+)
+
+// AppendCompact appends the compact binary representation of d to dst and returns the extended buffer.
+func (d *Decimal) AppendCompact(dst []byte) []byte {
+	buf := getInt()
+	defer putInt(buf)
+	c := d.coefficient(buf)
+	dst = binary.AppendVarint(dst, int64(d.exponent))
+	var sign uint64
+	if c.Sign() < 0 {
+		sign = _CompactNegative
+	}
+	if c.IsInt64() {
+		x := c.Int64()
+		mag := uint64(x)
+		if x < 0 {
+			mag = -mag
+		}
+		if mag <= _CompactMaxSmall {
+			return binary.AppendUvarint(dst, mag<<2|sign)
+		}
+	}
+	b := c.Bytes() // absolute value
+	dst = binary.AppendUvarint(dst, uint64(len(b))<<2|sign|_CompactLarge)
+	return append(dst, b...)
+}
+
+// DecodeCompact sets d to the value of the compact binary representation at the beginning of data and returns the number of bytes read.
+// It does not allocate if the coefficient fits in int64 and d holds an inline coefficient,
+// or d already holds a big coefficient with enough capacity (which is reused, so a decimal can decode a stream of values without allocation).
+// The limits of DecimalLimits are enforced. If the operation failed, d is unchanged.
+func (d *Decimal) DecodeCompact(data []byte) (int, error) {
+	exp, n := binary.Varint(data)
+	if n <= 0 || int64(int(exp)) != exp {
+		return 0, errors.New("compact decimal exponent is invalid")
+	}
+	h, m := binary.Uvarint(data[n:])
+	if m <= 0 {
+		return 0, errors.New("compact decimal coefficient is invalid")
+	}
+	n += m
+	if h&_CompactLarge == 0 { // at most 62 bits, fits inline
+		x := int64(h >> 2)
+		if h&_CompactNegative != 0 {
+			x = -x
+		}
+		if err := DecimalLimits.Check(&Decimal{small: x, inline: true, exponent: int(exp)}); err != nil {
+			return 0, err
+		}
+		if d.isInline() {
+			d.small, d.inline = x, true
+		} else { // reuse the big coefficient of d
+			d.integer.SetInt64(x)
+		}
+		d.exponent = int(exp)
+		return n, nil
+	}
+	size := h >> 2
+	if size > uint64(len(data)-n) {
+		return 0, errors.New("compact decimal is truncated")
+	}
+	c := getInt()
+	defer putInt(c)
+	c.SetBytes(data[n : n+int(size)])
+	n += int(size)
+	if h&_CompactNegative != 0 {
+		c.Neg(c)
+	}
+	if err := DecimalLimits.check(c, int(exp)); err != nil {
+		return 0, err
+	}
+	d.ensureInitialized() // allocates only if d is inline
+	d.integer.Set(c)
+	d.exponent = int(exp)
+	return n, nil
+}
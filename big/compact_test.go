@@ -0,0 +1,69 @@
+package big
+
+import (
+	"testing"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+func TestCompact(t *testing.T) {
+	// test AppendCompact
+	data := map[string][]byte{
+		"0":      {0x00, 0x00},
+		"19.99":  {0x03, 0xBC, 0x3E},
+		"-19.99": {0x03, 0xBE, 0x3E},
+		"1e3":    {0x06, 0x04},
+	}
+	for k, v := range data {
+		testing2.AssertEqual(t, MustParseDecimal(k).AppendCompact(nil), v)
+	}
+
+	// test round trip
+	values := []string{
+		"0", "1", "-1", "19.99", "-0.00000001", "4611686018427387903", "4611686018427387904", "-9223372036854775808",
+		"123456789012345678901234567890.123456789", "-1e-300", "1e300",
+	}
+	var buf []byte
+	for _, v := range values {
+		buf = MustParseDecimal(v).AppendCompact(buf)
+	}
+	d := new(Decimal)
+	for _, v := range values {
+		n, err := d.DecodeCompact(buf)
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.String(), MustParseDecimal(v).String())
+		buf = buf[n:]
+	}
+	testing2.AssertEqual(t, len(buf), 0)
+
+	// test allocations
+	buf = MustParseDecimal("-1234567.89").AppendCompact(nil)
+	allocs := testing.AllocsPerRun(100, func() {
+		d.DecodeCompact(buf)
+	})
+	testing2.AssertEqual(t, allocs, float64(0))
+	large := MustParseDecimal("123456789012345678901234567890").AppendCompact(nil)
+	stream := MustParseDecimal("-0.5").AppendCompact(large)
+	stream = MustParseDecimal("-98765432109876543210987654321").AppendCompact(stream)
+	d = new(Decimal)
+	d.DecodeCompact(large) // the big coefficient is reused then
+	allocs = testing.AllocsPerRun(100, func() {
+		for rest := stream; len(rest) > 0; {
+			n, _ := d.DecodeCompact(rest)
+			rest = rest[n:]
+		}
+	})
+	testing2.AssertEqual(t, allocs, float64(0))
+	testing2.AssertEqual(t, d.String(), "-98765432109876543210987654321")
+	n, _ := d.DecodeCompact(stream[len(large):])
+	testing2.AssertEqual(t, d.String(), "-0.5")
+	testing2.AssertEqual(t, n, len(MustParseDecimal("-0.5").AppendCompact(nil)))
+
+	// test invalid data
+	for _, v := range [][]byte{nil, {0x80}, {0x00}, {0x00, 0x80}, large[:len(large)-1]} {
+		d := MustParseDecimal("1.5")
+		_, err := d.DecodeCompact(v)
+		testing2.AssertNotEqual(t, err, nil)
+		testing2.AssertEqual(t, d.String(), "1.5")
+	}
+}
@@ -1,14 +1,11 @@
 package big
 
 import (
-	"bytes"
-	"fmt"
+	"math"
 	"math/big"
-	"regexp"
 	"strconv"
-	"strings"
-
-	"github.com/golang-plus/errors"
+	"sync"
+	"sync/atomic"
 )
 
 var (
@@ -17,15 +14,57 @@ var (
 )
 
 // Decimal represents a decimal which can handing fixed precision.
+// The coefficient is stored inline (small) while it fits in int64, and is promoted to big.Int (integer) when needed.
 type Decimal struct {
-	integer  *big.Int
+	integer  *big.Int // the coefficient if not inline
+	small    int64    // the coefficient if inline
+	inline   bool
 	exponent int
 }
 
+// ensureInitialized promotes the coefficient of d to d.integer which may be accessed afterwards.
+// It changes d, so it is only called by the methods modifying d, see coefficient for reading.
 func (d *Decimal) ensureInitialized() {
-	if d.integer == nil {
-		d.integer = new(big.Int)
+	if d.isInline() { // a new big.Int since d.integer may be shared by a copy of d
+		d.integer = new(big.Int).SetInt64(d.small)
+		d.inline = false
+	}
+}
+
+// isInline reports whether the coefficient of d is inline (a zero value decimal is inline zero).
+func (d *Decimal) isInline() bool {
+	return d.inline || d.integer == nil
+}
+
+// coefficient returns the coefficient of d without changing d, buf is set and returned if the coefficient is inline.
+// The returned integer must not be modified.
+func (d *Decimal) coefficient(buf *big.Int) *big.Int {
+	if d.isInline() {
+		return buf.SetInt64(d.small)
+	}
+	return d.integer
+}
+
+// demote stores the coefficient of d inline if it fits in int64.
+func (d *Decimal) demote() {
+	if !d.isInline() && d.integer.IsInt64() {
+		d.small, d.inline = d.integer.Int64(), true
+	}
+}
+
+// mulPow10Int64 returns x*10**n and a boolean indicating whether it does not overflow int64.
+func mulPow10Int64(x int64, n int) (int64, bool) {
+	if x == 0 {
+		return 0, true
+	}
+	if n < 0 || n > 18 {
+		return 0, false
 	}
+	p := int64(_Pow10Uint64[n])
+	if x > math.MaxInt64/p || x < math.MinInt64/p {
+		return 0, false
+	}
+	return x * p, true
 }
 
 // Sign returns:
@@ -33,24 +72,33 @@ func (d *Decimal) ensureInitialized() {
 //  0: if d == 0
 // +1: if d >  0
 func (d *Decimal) Sign() int {
-	d.ensureInitialized()
+	if d.isInline() {
+		switch {
+		case d.small < 0:
+			return -1
+		case d.small > 0:
+			return 1
+		}
+		return 0
+	}
 	return d.integer.Sign()
 }
 
 // IsZero reports whether the value of d is equal to zero.
 func (d *Decimal) IsZero() bool {
-	d.ensureInitialized()
+	if d.isInline() {
+		return d.small == 0
+	}
 	return d.integer.Sign() == 0
 }
 
 // Float32 returns the float32 value nearest to d and a boolean indicating whether is exact.
 func (d *Decimal) Float32() (float32, bool) {
-	d.ensureInitialized()
-	a := new(big.Rat).SetInt(d.integer)
+	a := new(big.Rat).SetInt(d.coefficient(new(big.Int)))
 	if d.exponent == 0 {
 		return a.Float32()
 	}
-	b := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), new(big.Int).Abs(big.NewInt(int64(d.exponent))), nil))
+	b := new(big.Rat).SetInt(pow10(absInt(d.exponent)))
 	if d.exponent > 0 {
 		b.Inv(b)
 	}
@@ -60,12 +108,11 @@ func (d *Decimal) Float32() (float32, bool) {
 
 // Float64 returns the float64 value nearest to d and a boolean indicating whether is exact.
 func (d *Decimal) Float64() (float64, bool) {
-	d.ensureInitialized()
-	a := new(big.Rat).SetInt(d.integer)
+	a := new(big.Rat).SetInt(d.coefficient(new(big.Int)))
 	if d.exponent == 0 {
 		return a.Float64()
 	}
-	b := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), new(big.Int).Abs(big.NewInt(int64(d.exponent))), nil))
+	b := new(big.Rat).SetInt(pow10(absInt(d.exponent)))
 	if d.exponent > 0 {
 		b.Inv(b)
 	}
@@ -75,99 +122,73 @@ func (d *Decimal) Float64() (float64, bool) {
 
 // Int64 returns the int64 value nearest to d and a boolean indicating whether is exact.
 func (d *Decimal) Int64() (int64, bool) {
-	d.ensureInitialized()
+	if d.isInline() && d.exponent <= 0 && d.exponent >= -18 {
+		return d.small / int64(_Pow10Uint64[-d.exponent]), d.exponent == 0
+	}
+	c := d.coefficient(new(big.Int))
 	if d.exponent == 0 {
-		return d.integer.Int64(), true
+		return c.Int64(), true
 	}
 	if d.exponent > 0 {
-		z := new(big.Int).Mul(d.integer, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.exponent)), nil))
+		z := new(big.Int).Mul(c, pow10(d.exponent))
 		return z.Int64(), true
 	}
 
-	z := new(big.Int).Quo(d.integer, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.exponent*-1)), nil))
+	z := new(big.Int).Quo(c, pow10(d.exponent*-1))
 	return z.Int64(), false
 }
 
-// String converts the floating-point number d to a string.
+// String converts the floating-point number d to a string (in the exponential form if the exponent exceeds DecimalLimits, see AppendText).
 func (d *Decimal) String() string {
-	d.ensureInitialized()
-	if d.integer.Cmp(big.NewInt(0)) == 0 { // euqal to zero
-		return "0"
-	}
-	str := d.integer.String()
-	if d.exponent == 0 { // value is the integer without exponent
-		return str
-	}
-	if d.exponent > 0 { // value is the integer with exponent
-		return str + strings.Repeat("0", d.exponent)
-	}
-
-	// has decimal digits
-	var buf bytes.Buffer
-	if strings.HasPrefix(str, "-") {
-		buf.WriteString("-")
-		str = str[1:]
-	}
-	p := len(str) - (d.exponent * -1)
-	if p <= 0 {
-		buf.WriteString("0.")
-		buf.WriteString(strings.Repeat("0", p*-1))
-		buf.WriteString(strings.TrimRight(str, "0"))
-	} else {
-		buf.WriteString(str[:p]) // integer part
-		decimals := strings.TrimRight(str[p:], "0")
-		if len(decimals) > 0 {
-			buf.WriteString(".")
-			buf.WriteString(decimals)
-		}
-	}
-	return buf.String()
+	return string(d.AppendText(nil))
 }
 
 // SetInt64 sets x to y and returns x.
 func (x *Decimal) SetInt64(y int64) *Decimal {
-	x.ensureInitialized()
-	x.integer.SetInt64(y)
+	x.small, x.inline = y, true
 	x.exponent = 0
 	return x
 }
 
-var (
-	_DecimalPattern = regexp.MustCompile(`^([-+]?\d+)(\.(\d+))?([eE]([-+]?\d+))?$`)
-)
-
 // SetString sets x to the value of y and returns x and a boolean indicating success.
-// If the operation failed, the value of d is undefined but the returned value is nil.
+// y is parsed by the grammar described in parse.go (e.g. "-123.45", "1.5e-3").
+// The limits of DecimalLimits are enforced. If the operation failed, the value of x is unchanged but the returned value is nil.
 func (x *Decimal) SetString(y string) (*Decimal, bool) {
-	x.ensureInitialized()
-	matches := _DecimalPattern.FindStringSubmatch(y)
-	if len(matches) != 6 {
+	if setDecimal(x, y, defaultParseOptions()) != nil {
 		return nil, false
 	}
-	decimals := strings.TrimRight(matches[3], "0")
-	integer := matches[1] + decimals
-	exponent := len(decimals) * -1
-	if len(matches[5]) > 0 {
-		exp, _ := strconv.ParseInt(matches[5], 10, 64)
-		exponent += int(exp)
-	}
-	x.integer.SetString(integer, 10)
-	x.exponent = exponent
+	return x, true
+}
 
+// SetBytes sets x to the value of y and returns x and a boolean indicating success.
+// It is the same as SetString but avoids converting y to string.
+func (x *Decimal) SetBytes(y []byte) (*Decimal, bool) {
+	if setDecimal(x, y, defaultParseOptions()) != nil {
+		return nil, false
+	}
 	return x, true
 }
 
-// SetFloat64 sets x to y and returns x.
+// SetFloat64 sets x to y and returns x. x is not changed if y is NaN or an infinity.
 func (x *Decimal) SetFloat64(y float64) *Decimal {
-	x.ensureInitialized()
-	x.SetString(strconv.FormatFloat(y, 'f', -1, 64))
+	setDecimal(x, strconv.FormatFloat(y, 'f', -1, 64), StrictParseOptions)
 	return x
 }
 
 // Copy sets x to y and returns x. y is not changed.
+// The coefficient of x is stored inline if it fits in int64.
 func (x *Decimal) Copy(y *Decimal) *Decimal {
+	switch {
+	case y.isInline():
+		x.small, x.inline = y.small, true
+		x.exponent = y.exponent
+		return x
+	case y.integer.IsInt64():
+		x.small, x.inline = y.integer.Int64(), true
+		x.exponent = y.exponent
+		return x
+	}
 	x.ensureInitialized()
-	y.ensureInitialized()
 	x.integer.Set(y.integer)
 	x.exponent = y.exponent
 	return x
@@ -175,6 +196,12 @@ func (x *Decimal) Copy(y *Decimal) *Decimal {
 
 // Abs sets d to the value |d| (the absolute value of d) and returns d.
 func (d *Decimal) Abs() *Decimal {
+	if d.isInline() && d.small != math.MinInt64 {
+		if d.small < 0 {
+			d.small = -d.small
+		}
+		return d
+	}
 	d.ensureInitialized()
 	d.integer.Abs(d.integer)
 	return d
@@ -182,21 +209,168 @@ func (d *Decimal) Abs() *Decimal {
 
 // Neg sets d to the value of d with its sign negated, and returns d.
 func (d *Decimal) Neg() *Decimal {
+	if d.isInline() && d.small != math.MinInt64 {
+		d.small = -d.small
+		return d
+	}
 	d.ensureInitialized()
 	d.integer.Neg(d.integer)
 	return d
 }
 
-func (x *Decimal) align(y *Decimal) {
-	if x.exponent != y.exponent {
-		diff := new(big.Int).Abs(new(big.Int).Sub(new(big.Int).Abs(big.NewInt(int64(x.exponent))), new(big.Int).Abs(big.NewInt(int64(y.exponent)))))
-		if x.exponent > y.exponent {
-			x.integer.Mul(x.integer, new(big.Int).Exp(big.NewInt(10), diff, nil))
-			x.exponent = y.exponent
-		} else {
-			y.integer.Mul(y.integer, new(big.Int).Exp(big.NewInt(10), diff, nil))
-			y.exponent = x.exponent
+const (
+	_Pow10CacheSize = 1024
+)
+
+var (
+	// cached powers of ten (computed on demand), shared by goroutines.
+	_Pow10Cache [_Pow10CacheSize]atomic.Pointer[big.Int]
+
+	_IntOne = big.NewInt(1)
+
+	// _Pow10Uint64 holds 10**i for i in [0, 19].
+	_Pow10Uint64 = [20]uint64{
+		1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+		1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19,
+	}
+
+	// scratch integers.
+	_IntPool = sync.Pool{
+		New: func() interface{} {
+			return new(big.Int)
+		},
+	}
+)
+
+// pow10 returns 10**n (n >= 0), the returned value must not be modified.
+func pow10(n int) *big.Int {
+	if n >= _Pow10CacheSize {
+		return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+	}
+	if p := _Pow10Cache[n].Load(); p != nil {
+		return p
+	}
+	_Pow10Cache[n].CompareAndSwap(nil, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil))
+	return _Pow10Cache[n].Load()
+}
+
+func absInt64(x int64) int64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func absInt(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// getInt returns a scratch integer which should be put back by putInt.
+func getInt() *big.Int {
+	return _IntPool.Get().(*big.Int)
+}
+
+func putInt(x *big.Int) {
+	_IntPool.Put(x)
+}
+
+// trimFraction removes the trailing zeros of the decimal part of d (as SetString does),
+// the coefficient is stored inline if it fits in int64.
+func (d *Decimal) trimFraction() {
+	d.demote()
+	if d.isInline() {
+		if d.small == 0 {
+			d.exponent = 0
 		}
+		for d.exponent < 0 && d.small%10 == 0 {
+			d.small /= 10
+			d.exponent++
+		}
+		return
+	}
+	ten := big.NewInt(10)
+	q, r := new(big.Int), new(big.Int)
+	for d.exponent < 0 {
+		if q.QuoRem(d.integer, ten, r); r.Sign() != 0 {
+			break
+		}
+		d.integer.Set(q)
+		d.exponent++
+	}
+	d.demote()
+}
+
+// scaledInteger returns d*10**scale and a boolean indicating whether is exact (no non-zero decimal digits discarded).
+func (d *Decimal) scaledInteger(scale int) (*big.Int, bool) {
+	z := new(big.Int)
+	z.Set(d.coefficient(z))
+	if shift := d.exponent + scale; shift >= 0 {
+		return z.Mul(z, pow10(shift)), true
+	}
+	r := getInt()
+	defer putInt(r)
+	z.QuoRem(z, pow10((d.exponent+scale)*-1), r)
+	return z, r.Sign() == 0
+}
+
+// scaledIntegerWithin returns d*10**scale if it is exact and has at most precision digits, otherwise nil and
+// two booleans indicating whether it is exact and whether it fits precision.
+// The sizes are checked before scaling, so a huge exponent is rejected without computing a huge power of ten.
+func (d *Decimal) scaledIntegerWithin(precision, scale int) (*big.Int, bool, bool) {
+	if d.IsZero() {
+		return new(big.Int), true, true
+	}
+	n := int64(numDigits(d.coefficient(new(big.Int))))
+	shift := int64(d.exponent) + int64(scale)
+	if shift < 0 && shift*-1 >= n { // the non-zero coefficient is not a multiple of 10**-shift
+		return nil, false, true
+	}
+	if shift >= 0 && n+shift > int64(precision) {
+		return nil, true, false
+	}
+	z, exact := d.scaledInteger(scale)
+	if !exact {
+		return nil, false, true
+	}
+	if numDigits(z) > precision {
+		return nil, true, false
+	}
+	return z, true, true
+}
+
+// alignInline aligns the inline coefficients of x and y (as align) and reports whether it succeeds without overflow.
+// x and y are not changed if it fails.
+func (x *Decimal) alignInline(y *Decimal) bool {
+	if !x.isInline() || !y.isInline() {
+		return false
+	}
+	switch {
+	case x.exponent > y.exponent:
+		v, ok := mulPow10Int64(x.small, x.exponent-y.exponent)
+		if !ok {
+			return false
+		}
+		x.small, x.exponent = v, y.exponent
+	case x.exponent < y.exponent:
+		v, ok := mulPow10Int64(y.small, y.exponent-x.exponent)
+		if !ok {
+			return false
+		}
+		y.small, y.exponent = v, x.exponent
+	}
+	return true
+}
+
+func (x *Decimal) align(y *Decimal) {
+	if x.exponent > y.exponent {
+		x.integer.Mul(x.integer, pow10(x.exponent-y.exponent))
+		x.exponent = y.exponent
+	} else if x.exponent < y.exponent {
+		y.integer.Mul(y.integer, pow10(y.exponent-x.exponent))
+		y.exponent = x.exponent
 	}
 }
 
@@ -205,6 +379,15 @@ func (x *Decimal) align(y *Decimal) {
 //  0 if d == y (includes: -0 == 0, -Inf == -Inf, and +Inf == +Inf)
 // +1 if d > y
 func (x *Decimal) Cmp(y *Decimal) int {
+	if x.alignInline(y) {
+		switch {
+		case x.small < y.small:
+			return -1
+		case x.small > y.small:
+			return 1
+		}
+		return 0
+	}
 	x.ensureInitialized()
 	y.ensureInitialized()
 	x.align(y)
@@ -212,7 +395,21 @@ func (x *Decimal) Cmp(y *Decimal) int {
 }
 
 // Add sets d to the sum of d and y and returns x.
+// It panics with the error of DecimalLimits.Add if the limits are exceeded.
 func (x *Decimal) Add(y *Decimal) *Decimal {
+	if err := DecimalLimits.Add(x, y); err != nil {
+		panic(err)
+	}
+	return x
+}
+
+func (x *Decimal) add(y *Decimal) *Decimal {
+	if x.alignInline(y) {
+		if z := x.small + y.small; (z > x.small) == (y.small > 0) {
+			x.small = z
+			return x
+		}
+	}
 	x.ensureInitialized()
 	y.ensureInitialized()
 	x.align(y)
@@ -221,7 +418,21 @@ func (x *Decimal) Add(y *Decimal) *Decimal {
 }
 
 // Sub sets d to the difference x-y and returns x.
+// It panics with the error of DecimalLimits.Sub if the limits are exceeded.
 func (x *Decimal) Sub(y *Decimal) *Decimal {
+	if err := DecimalLimits.Sub(x, y); err != nil {
+		panic(err)
+	}
+	return x
+}
+
+func (x *Decimal) sub(y *Decimal) *Decimal {
+	if x.alignInline(y) {
+		if z := x.small - y.small; (z < x.small) == (y.small > 0) {
+			x.small = z
+			return x
+		}
+	}
 	x.ensureInitialized()
 	y.ensureInitialized()
 	x.align(y)
@@ -230,53 +441,100 @@ func (x *Decimal) Sub(y *Decimal) *Decimal {
 }
 
 // Mul sets x to the product x*y and returns x.
+// It panics with the error of DecimalLimits.Mul if the limits are exceeded.
 func (x *Decimal) Mul(y *Decimal) *Decimal {
+	if err := DecimalLimits.Mul(x, y); err != nil {
+		panic(err)
+	}
+	return x
+}
+
+func (x *Decimal) mul(y *Decimal) *Decimal {
+	if x.isInline() && y.isInline() {
+		if y.small == 0 { // *0
+			x.small, x.exponent = 0, 0
+			return x
+		}
+		if z := x.small * y.small; z/y.small == x.small && !(x.small == math.MinInt64 && y.small == -1) {
+			x.small = z
+			x.exponent += y.exponent
+			return x
+		}
+	}
+	buf := getInt()
+	defer putInt(buf)
+	yc := y.coefficient(buf) // y is not changed
 	x.ensureInitialized()
-	y.ensureInitialized()
-	if y.integer.Sign() == 0 { // *0
+	if yc.Sign() == 0 { // *0
 		x.integer.SetInt64(0)
 		x.exponent = 0
 		return x
 	}
-	x.integer.Mul(x.integer, y.integer)
+	x.integer.Mul(x.integer, yc)
 	x.exponent += y.exponent
 	return x
 }
 
 // Quo sets x to the quotient x/y and return x.
-// Please set MaxDecimalDigitis for indivisible quotient.
+// Please set MaxDecimalDigitis for indivisible quotient, which is truncated to MaxDecimalDigits decimals
+// (or to the exponent of x/y if it is less than -MaxDecimalDigits).
 func (x *Decimal) Quo(y *Decimal) *Decimal {
+	return x.QuoWithMode(y, ToZero)
+}
+
+// QuoWithMode sets x to the quotient x/y and return x.
+// Indivisible quotient is rounded with given rounding mode to MaxDecimalDigits decimals
+// (or to the exponent of x/y if it is less than -MaxDecimalDigits).
+// It panics with the error of DecimalLimits.QuoWithMode if the limits are exceeded.
+func (x *Decimal) QuoWithMode(y *Decimal, mode RoundingMode) *Decimal {
+	if err := DecimalLimits.QuoWithMode(x, y, mode); err != nil {
+		panic(err)
+	}
+	return x
+}
+
+func (x *Decimal) quo(y *Decimal, mode RoundingMode) *Decimal {
+	buf := getInt()
+	defer putInt(buf)
+	yc := y.coefficient(buf) // y is not changed
 	x.ensureInitialized()
-	y.ensureInitialized()
-	if y.integer.Sign() == 0 { // /0
+	if yc.Sign() == 0 { // /0
 		x.integer.SetInt64(0)
 		x.exponent = 0
 		return x
 	}
 	// modulus x%y == 0
-	if z, r := new(big.Int).QuoRem(x.integer, y.integer, new(big.Int)); r.Sign() == 0 {
+	r := getInt()
+	defer putInt(r)
+	if z, _ := new(big.Int).QuoRem(x.integer, yc, r); r.Sign() == 0 {
 		x.integer = z
 		x.exponent -= y.exponent
+		x.demote()
 		return x
 	}
-	// modulus x%y > 0
-	var buf bytes.Buffer
-	if x.integer.Sign()*y.integer.Sign() == -1 {
-		buf.WriteString("-")
-	}
-	xi := new(big.Int).Abs(x.integer)
-	yi := new(big.Int).Abs(y.integer)
+	// modulus x%y > 0: scale x by 10**k once so that the quotient has MaxDecimalDigits decimals
 	exp := x.exponent - y.exponent
-	z, r := new(big.Int).QuoRem(xi, yi, new(big.Int))
-	buf.WriteString(z.String())
-	for r.Sign() != 0 && exp*-1 < int(MaxDecimalDigits) {
-		r.Mul(r, big.NewInt(10))
-		z, r = new(big.Int).QuoRem(r, yi, new(big.Int))
-		buf.WriteString(z.String())
-		exp -= 1
-	}
-	str := fmt.Sprintf("%se%d", buf.String(), exp)
-	x.SetString(str)
+	k := int(MaxDecimalDigits) + exp
+	if k < 0 { // the quotient already has more decimals
+		k = 0
+	}
+	sign := x.integer.Sign() * yc.Sign()
+	x.integer.Mul(x.integer, pow10(k))
+	z, _ := new(big.Int).QuoRem(x.integer, yc, r)
+	class := discardedZero
+	if r.Sign() != 0 {
+		class = classify(r, yc)
+	}
+	if mode.roundUp(class, z.Bit(0) == 1) {
+		if sign < 0 {
+			z.Sub(z, _IntOne)
+		} else {
+			z.Add(z, _IntOne)
+		}
+	}
+	x.integer = z
+	x.exponent = exp - k
+	x.trimFraction()
 	return x
 }
 
@@ -287,50 +545,7 @@ func (x *Decimal) Div(y *Decimal) *Decimal {
 
 // RoundToNearestEven rounds (IEEE 754-2008, round to nearest, ties to even) the floating-point number x with given precision (the number of digits after the decimal point).
 func (d *Decimal) RoundToNearestEven(precision uint) *Decimal {
-	d.ensureInitialized()
-	prec := int(precision)
-	if d.IsZero() || d.exponent > 0 || d.exponent*-1 <= prec { // rounding needless
-		return d
-	}
-
-	str := d.integer.String()
-	var sign, part1, part2 string
-	if strings.HasPrefix(str, "-") {
-		sign = "-"
-		str = str[1:]
-	}
-	if len(str) < d.exponent*-1 {
-		str = strings.Repeat("0", (d.exponent*-1)-len(str)+1) + str
-	}
-	part1 = str[:len(str)+d.exponent]
-	part2 = str[len(part1):]
-	isRoundUp := false
-	switch part2[prec : prec+1] {
-	case "6", "7", "8", "9":
-		isRoundUp = true
-	case "5":
-		if len(part2) > prec+1 { // found decimals back of "5"
-			isRoundUp = true
-		} else {
-			var neighbor string
-			if prec == 0 { // get neighbor from integer part
-				neighbor = part1[len(part1)-1:]
-			} else {
-				neighbor = part2[prec-1 : prec]
-			}
-			switch neighbor {
-			case "1", "3", "5", "7", "9":
-				isRoundUp = true
-			}
-		}
-	}
-	z, _ := new(big.Int).SetString(sign+part1+part2[:prec], 10)
-	if isRoundUp {
-		z.Add(z, big.NewInt(int64(d.integer.Sign())))
-	}
-	d.integer = z
-	d.exponent = prec * -1
-	return d
+	return d.round(precision, ToNearestEven)
 }
 
 // Round is short to RoundToNearestEven.
@@ -340,36 +555,12 @@ func (d *Decimal) Round(precision uint) *Decimal {
 
 // RoundToNearestAway rounds (IEEE 754-2008, round to nearest, ties away from zero) the floating-point number x with given precision (the number of digits after the decimal point).
 func (d *Decimal) RoundToNearestAway(precision uint) *Decimal {
-	d.ensureInitialized()
-	prec := int(precision)
-	if d.IsZero() || d.exponent > 0 || d.exponent*-1 <= prec { // rounding needless
-		return d
-	}
-
-	diff := new(big.Int).Sub(new(big.Int).Abs(big.NewInt(int64(d.exponent))), big.NewInt(int64(prec+1)))
-	d.integer.Quo(d.integer, new(big.Int).Exp(big.NewInt(10), diff, nil))
-	factor := big.NewInt(int64(5))
-	if d.integer.Sign() == -1 {
-		factor.Neg(factor)
-	}
-	d.integer.Add(d.integer, factor)
-	d.integer.Quo(d.integer, big.NewInt(10))
-	d.exponent = prec * -1
-	return d
+	return d.round(precision, ToNearestAway)
 }
 
 // RoundToZero rounds (IEEE 754-2008, round towards zero) the floating-point number x with given precision (the number of digits after the decimal point).
 func (d *Decimal) RoundToZero(precision uint) *Decimal {
-	d.ensureInitialized()
-	prec := int(precision)
-	if d.IsZero() || d.exponent > 0 || d.exponent*-1 <= prec { // rounding needless
-		return d
-	}
-
-	diff := new(big.Int).Sub(new(big.Int).Abs(big.NewInt(int64(d.exponent))), big.NewInt(int64(prec)))
-	d.integer.Quo(d.integer, new(big.Int).Exp(big.NewInt(10), diff, nil))
-	d.exponent = prec * -1
-	return d
+	return d.round(precision, ToZero)
 }
 
 // Truncate is same as RoundToZero.
@@ -384,37 +575,139 @@ func (d *Decimal) RoundDown(precision uint) *Decimal {
 
 // RoundAwayFromZero rounds (no IEEE 754-2008, round away from zero) to floating-point number d with given precision.
 func (d *Decimal) RoundAwayFromZero(precision uint) *Decimal {
-	d.ensureInitialized()
-	prec := int(precision)
-	if d.IsZero() || d.exponent > 0 || d.exponent*-1 <= prec { // rounding needless
+	return d.round(precision, AwayFromZero)
+}
+
+// RoundUp is same as RoundAwayFromZero.
+func (d *Decimal) RoundUp(precision uint) *Decimal {
+	return d.RoundAwayFromZero(precision)
+}
+
+// RoundingMode determines how a decimal is rounded.
+type RoundingMode byte
+
+// The rounding modes.
+const (
+	ToNearestEven RoundingMode = iota // RoundToNearestEven
+	ToNearestAway                     // RoundToNearestAway
+	ToZero                            // RoundToZero
+	AwayFromZero                      // RoundAwayFromZero
+)
+
+// RoundWithMode rounds d with given precision and rounding mode and returns d.
+// An unknown mode rounds to nearest, ties to even.
+func (d *Decimal) RoundWithMode(precision uint, mode RoundingMode) *Decimal {
+	return d.round(precision, mode)
+}
+
+// discarded classifies the digits discarded by rounding.
+type discarded byte
+
+const (
+	discardedZero      discarded = iota // all zeros (exact)
+	discardedBelowHalf                  // greater than zero and less than half
+	discardedHalf                       // exactly half
+	discardedAboveHalf                  // greater than half
+)
+
+// roundUp reports whether the magnitude of the kept digits (the last of which is odd or not) should be incremented by mode.
+func (mode RoundingMode) roundUp(class discarded, odd bool) bool {
+	switch mode {
+	case ToNearestAway:
+		return class >= discardedHalf
+	case ToZero:
+		return false
+	case AwayFromZero:
+		return class != discardedZero
+	}
+	return class == discardedAboveHalf || class == discardedHalf && odd // ToNearestEven (and unknown modes)
+}
+
+// classify returns the class of r (the non-zero remainder) against the divisor p, r is changed.
+func classify(r, p *big.Int) discarded {
+	switch c := r.Lsh(r, 1).CmpAbs(p); {
+	case c < 0:
+		return discardedBelowHalf
+	case c == 0:
+		return discardedHalf
+	}
+	return discardedAboveHalf
+}
+
+// round rounds d with given precision (the number of digits after the decimal point) and rounding mode and returns d.
+func (d *Decimal) round(precision uint, mode RoundingMode) *Decimal {
+	return d.roundTo(int(precision)*-1, mode)
+}
+
+// roundTo rounds d to a multiple of 10**exp with given rounding mode and returns d.
+// The discarded digits are classified once by a division against a cached power of ten.
+func (d *Decimal) roundTo(exp int, mode RoundingMode) *Decimal {
+	if d.IsZero() || d.exponent >= exp { // rounding needless
+		return d
+	}
+	n := exp - d.exponent // number of digits discarded
+
+	if d.inline && n <= 18 {
+		p := int64(_Pow10Uint64[n])
+		q, r := d.small/p, d.small%p // r has the sign of d
+		class := discardedZero
+		if r != 0 {
+			switch r = absInt64(r) * 2; {
+			case r < p:
+				class = discardedBelowHalf
+			case r == p:
+				class = discardedHalf
+			default:
+				class = discardedAboveHalf
+			}
+		}
+		if mode.roundUp(class, q%2 != 0) {
+			if d.small < 0 {
+				q--
+			} else {
+				q++
+			}
+		}
+		d.small, d.exponent = q, exp
 		return d
 	}
 
+	d.ensureInitialized()
 	sign := d.integer.Sign()
-	diff := new(big.Int).Sub(new(big.Int).Abs(big.NewInt(int64(d.exponent))), big.NewInt(int64(prec)))
-	if _, r := d.integer.QuoRem(d.integer, new(big.Int).Exp(big.NewInt(10), diff, nil), new(big.Int)); r.Sign() != 0 {
-		d.integer.Add(d.integer, big.NewInt(int64(1*sign))) // round up
+	class := discardedBelowHalf
+	if n > d.integer.BitLen()/3+1 { // more than all digits discarded, less than half (avoids a large power of ten)
+		d.integer.SetInt64(0)
+	} else {
+		r := getInt()
+		defer putInt(r)
+		d.integer.QuoRem(d.integer, pow10(n), r)
+		if r.Sign() == 0 {
+			class = discardedZero
+		} else if mode != ToZero && mode != AwayFromZero { // the directed modes need not compare with half
+			class = classify(r, pow10(n))
+		}
+	}
+	if mode.roundUp(class, d.integer.Bit(0) == 1) {
+		if sign < 0 {
+			d.integer.Sub(d.integer, _IntOne)
+		} else {
+			d.integer.Add(d.integer, _IntOne)
+		}
 	}
-	d.exponent = prec * -1
+	d.exponent = exp
+	d.demote()
 	return d
 }
 
-// RoundUp is same as RoundAwayFromZero.
-func (d *Decimal) RoundUp(precision uint) *Decimal {
-	return d.RoundAwayFromZero(precision)
-}
-
 // NewDecimal returns a new decimal.
 func NewDecimal(number float64) *Decimal {
 	return new(Decimal).SetFloat64(number)
 }
 
 // ParseDecimal returns a new decimal by parsing decimal string.
+// A *ParseError is returned if str is invalid (see the grammar in parse.go) or exceeds DecimalLimits.
 func ParseDecimal(str string) (*Decimal, error) {
-	if d, ok := new(Decimal).SetString(str); ok {
-		return d, nil
-	}
-	return nil, errors.Newf("decimal string %q is invalid", str)
+	return ParseDecimalWith(str, defaultParseOptions())
 }
 
 // MustParseDecimal is similar to ParseDecimal but panics if error occurred.
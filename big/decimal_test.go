@@ -1,6 +1,9 @@
 package big
 
 import (
+	"math"
+	"strings"
+	"sync"
 	"testing"
 
 	testing2 "github.com/golang-plus/testing"
@@ -39,6 +42,14 @@ func TestDecimal(t *testing.T) {
 		d := NewDecimal(k)
 		testing2.AssertEqual(t, d.String(), v)
 	}
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} { // not changed
+		testing2.AssertEqual(t, NewDecimal(v).String(), "0")
+		testing2.AssertEqual(t, MustParseDecimal("1.5").SetFloat64(v).String(), "1.5")
+	}
+	float64Allocs := testing.AllocsPerRun(100, func() {
+		NewDecimal(19.99)
+	})
+	testing2.AssertEqual(t, float64Allocs <= 2, true) // the decimal and the formatted float, no big.Int
 
 	// test Copy
 	d1 := NewDecimal(-1000)
@@ -230,4 +241,221 @@ func TestDecimal(t *testing.T) {
 			testing2.AssertEqual(t, d.Round(k).String(), v2)
 		}
 	}
+
+	// test inline coefficient (promoted to big.Int on overflow)
+	data9 := map[string][3]string{ // x, y: x+y, x-y, x*y
+		"9223372036854775807 1":     {"9223372036854775808", "9223372036854775806", "9223372036854775807"},
+		"-9223372036854775808 1":    {"-9223372036854775807", "-9223372036854775809", "-9223372036854775808"},
+		"-9223372036854775808 -1":   {"-9223372036854775809", "-9223372036854775807", "9223372036854775808"},
+		"922337203685477580.7 0.01": {"922337203685477580.71", "922337203685477580.69", "9223372036854775.807"},
+		"4294967296 4294967296":     {"8589934592", "0", "18446744073709551616"},
+		"1e18 1e-18":                {"1000000000000000000.000000000000000001", "999999999999999999.999999999999999999", "1"},
+		"0 -0.5":                    {"-0.5", "0.5", "0"},
+	}
+	for k, v := range data9 {
+		operands := strings.Split(k, " ")
+		x, y := MustParseDecimal(operands[0]), MustParseDecimal(operands[1])
+		testing2.AssertEqual(t, new(Decimal).Copy(x).Add(y).String(), v[0])
+		testing2.AssertEqual(t, new(Decimal).Copy(x).Sub(y).String(), v[1])
+		testing2.AssertEqual(t, new(Decimal).Copy(x).Mul(y).String(), v[2])
+		testing2.AssertEqual(t, new(Decimal).Copy(x).Add(y).Cmp(x), y.Sign())
+		testing2.AssertEqual(t, x.String(), MustParseDecimal(operands[0]).String())
+	}
+	min := new(Decimal).SetInt64(math.MinInt64)
+	testing2.AssertEqual(t, new(Decimal).Copy(min).Neg().String(), "9223372036854775808")
+	testing2.AssertEqual(t, new(Decimal).Copy(min).Abs().String(), "9223372036854775808")
+	testing2.AssertEqual(t, min.Cmp(MustParseDecimal("-9223372036854775809")), 1)
+	var zero Decimal
+	testing2.AssertEqual(t, zero.Add(NewDecimal(1.5)).String(), "1.5")
+
+	// test allocations of small values
+	x, y, z, w := MustParseDecimal("19.99"), MustParseDecimal("0.075"), new(Decimal), new(Decimal)
+	allocs := testing.AllocsPerRun(100, func() {
+		z.Copy(x).Mul(y).Add(w.Copy(x)).Sub(w.Copy(y)).Cmp(w.Copy(x))
+	})
+	testing2.AssertEqual(t, allocs, float64(0))
+	testing2.AssertEqual(t, z.String(), "21.41425")
+
+	// test cached powers of ten
+	var wg sync.WaitGroup
+	powers := make([][]string, 8) // asserted after wg.Wait (not in the goroutines)
+	for i := range powers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for n := 0; n < _Pow10CacheSize+10; n += 7 {
+				powers[i] = append(powers[i], pow10(n).String())
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, v := range powers {
+		for i, n := 0, 0; n < _Pow10CacheSize+10; i, n = i+1, n+7 {
+			testing2.AssertEqual(t, v[i], "1"+strings.Repeat("0", n))
+		}
+	}
+	testing2.AssertEqual(t, pow10(30), pow10(30))
+
+	// test reading concurrently (d is not changed, kept inline)
+	shared := MustParseDecimal("19.99")
+	type reading struct {
+		f   float64
+		n   int64
+		err error
+		key []byte
+		str string
+	}
+	readings := make([]reading, 8) // asserted after wg.Wait (not in the goroutines)
+	for i := range readings {
+		wg.Add(1)
+		go func(r *reading) {
+			defer wg.Done()
+			r.f, _ = shared.Float64()
+			r.n, _ = shared.Int64()
+			r.err = DecimalLimits.Check(shared)
+			r.key = shared.AppendSortableKey(nil)
+			r.str = shared.String()
+		}(&readings[i])
+	}
+	wg.Wait()
+	for _, r := range readings {
+		testing2.AssertEqual(t, r.f, 19.99)
+		testing2.AssertEqual(t, r.n, int64(19))
+		testing2.AssertEqual(t, r.err, nil)
+		testing2.AssertEqual(t, r.key, MustParseDecimal("19.99").AppendSortableKey(nil))
+		testing2.AssertEqual(t, r.str, "19.99")
+	}
+	testing2.AssertEqual(t, shared.inline, true)
+
+	// test results fitting in int64 stored inline
+	testing2.AssertEqual(t, MustParseDecimal("1").Quo(NewDecimal(8)).inline, true)
+	testing2.AssertEqual(t, MustParseDecimal("12345678901234567890123456789").Quo(MustParseDecimal("1234567890123456789012345678.9")).inline, true)
+	testing2.AssertEqual(t, MustParseDecimal("9223372036854775807").inline, true)
+	testing2.AssertEqual(t, new(Decimal).Copy(MustParseDecimal("1e30").Sub(MustParseDecimal("1e30"))).inline, true)
+
+	// test allocations of aligning big values
+	x, y = MustParseDecimal("123456789012345678901234567890.5"), MustParseDecimal("-0.001")
+	z.Copy(x).Add(y).Sub(y).Cmp(y)
+	allocs = testing.AllocsPerRun(100, func() {
+		z.Copy(x).Add(y).Sub(y).Cmp(y)
+	})
+	testing2.AssertEqual(t, allocs, float64(0))
+	testing2.AssertEqual(t, z.String(), "123456789012345678901234567890.5")
+
+	// test rounding modes (inline and big coefficients)
+	data10 := map[string][4]string{ // ToNearestEven, ToNearestAway, ToZero, AwayFromZero (precision 2)
+		"1.005":                              {"1", "1.01", "1", "1.01"},
+		"1.015":                              {"1.02", "1.02", "1.01", "1.02"},
+		"-1.015":                             {"-1.02", "-1.02", "-1.01", "-1.02"},
+		"1.0149999":                          {"1.01", "1.01", "1.01", "1.02"},
+		"1.0150001":                          {"1.02", "1.02", "1.01", "1.02"},
+		"-0.004":                             {"0", "0", "0", "-0.01"},
+		"0.005":                              {"0", "0.01", "0", "0.01"},
+		"1e-30":                              {"0", "0", "0", "0.01"},
+		"-9.999":                             {"-10", "-10", "-9.99", "-10"},
+		"1.5":                                {"1.5", "1.5", "1.5", "1.5"},
+		"123456789012345678901234567890.125": {"123456789012345678901234567890.12", "123456789012345678901234567890.13", "123456789012345678901234567890.12", "123456789012345678901234567890.13"},
+		"-0.00500000000000000000000000000000000000000000001": {"-0.01", "-0.01", "0", "-0.01"},
+		"0.00500000000000000000000000000000000000000000000":  {"0", "0.01", "0", "0.01"},
+	}
+	modes := []RoundingMode{ToNearestEven, ToNearestAway, ToZero, AwayFromZero}
+	for k, v := range data10 {
+		for i, mode := range modes {
+			testing2.AssertEqual(t, MustParseDecimal(k).RoundWithMode(2, mode).String(), v[i])
+			d := MustParseDecimal(k)
+			d.ensureInitialized() // big coefficient
+			testing2.AssertEqual(t, d.RoundWithMode(2, mode).String(), v[i])
+		}
+	}
+	d = &Decimal{small: 2500, inline: true, exponent: -3} // trailing zeros in the coefficient
+	testing2.AssertEqual(t, d.RoundToNearestEven(0).String(), "2")
+
+	// test Quo rounding
+	MaxDecimalDigits = 5
+	data11 := map[string][2]string{ // x/y: ToZero, ToNearestEven
+		"2/3":            {"0.66666", "0.66667"},
+		"-2/3":           {"-0.66666", "-0.66667"},
+		"2/-3":           {"-0.66666", "-0.66667"},
+		"1/7":            {"0.14285", "0.14286"},
+		"1/8":            {"0.125", "0.125"},
+		"1/3e-7":         {"3333333.33333", "3333333.33333"},
+		"1e-9/3":         {"0", "0"},
+		"2e-5/3":         {"0", "0.00001"},
+		"0.000015/1":     {"0.000015", "0.000015"},
+		"0.000015/3":     {"0.000005", "0.000005"},
+		"0.000025/2":     {"0.000012", "0.000012"},
+		"0.000035/2":     {"0.000017", "0.000018"},
+		"1e10/3":         {"3333333333.33333", "3333333333.33333"},
+		"123456789e3/7":  {"17636684142.85714", "17636684142.85714"},
+		"-1.0837/0.0004": {"-2709.25", "-2709.25"},
+	}
+	for k, v := range data11 {
+		operands := strings.Split(k, "/")
+		for i, mode := range []RoundingMode{ToZero, ToNearestEven} {
+			testing2.AssertEqual(t, MustParseDecimal(operands[0]).QuoWithMode(MustParseDecimal(operands[1]), mode).String(), v[i])
+		}
+		testing2.AssertEqual(t, MustParseDecimal(operands[0]).Quo(MustParseDecimal(operands[1])).String(), v[0]) // truncated
+	}
+
+	// test quotients with more decimals than MaxDecimalDigits (rounded at the exponent of x/y, no power of ten needed)
+	defer func(l Limits) { DecimalLimits = l }(DecimalLimits)
+	DecimalLimits = Limits{} // opt out, the exponents exceed the default limits
+
+	data12 := map[string][4]string{ // ToNearestEven, ToNearestAway, ToZero, AwayFromZero
+		"1e-20000000/3":  {"0", "0", "0", "1e-20000000"},
+		"-1e-20000000/3": {"0", "0", "0", "-1e-20000000"},
+		"4e-7/3":         {"1e-7", "1e-7", "1e-7", "2e-7"},
+		"-5e-7/3":        {"-2e-7", "-2e-7", "-1e-7", "-2e-7"},
+		"1.6e-5/3":       {"5e-6", "5e-6", "5e-6", "6e-6"},
+		"123e-8/0.99":    {"1e-6", "1e-6", "1e-6", "2e-6"},
+	}
+	for k, v := range data12 {
+		operands := strings.Split(k, "/")
+		for i, mode := range []RoundingMode{ToNearestEven, ToNearestAway, ToZero, AwayFromZero} {
+			z := MustParseDecimal(operands[0]).QuoWithMode(MustParseDecimal(operands[1]), mode)
+			testing2.AssertEqual(t, z.Cmp(MustParseDecimal(v[i])), 0)
+			testing2.AssertEqual(t, coefficientDigits(z) <= 2, true)
+		}
+	}
+}
+
+func benchmarkDecimals() (*Decimal, *Decimal, *Decimal) {
+	return MustParseDecimal("123456789012345678901234567890.5"), MustParseDecimal("-9876543210987654321.12345"), new(Decimal)
+}
+
+func BenchmarkAdd(b *testing.B) {
+	x, y, z := benchmarkDecimals()
+	for i := 0; i < b.N; i++ {
+		z.Copy(x).Add(y)
+	}
+}
+
+func BenchmarkCmp(b *testing.B) {
+	x, y, z := benchmarkDecimals()
+	for i := 0; i < b.N; i++ {
+		z.Copy(x).Cmp(y)
+	}
+}
+
+func BenchmarkRound(b *testing.B) {
+	_, y, z := benchmarkDecimals()
+	for i := 0; i < b.N; i++ {
+		z.Copy(y).RoundAwayFromZero(2)
+	}
+}
+
+func BenchmarkRoundToNearestEven(b *testing.B) {
+	x, _, z := benchmarkDecimals()
+	for i := 0; i < b.N; i++ {
+		z.Copy(x).RoundToNearestEven(0)
+	}
+}
+
+func BenchmarkQuo(b *testing.B) {
+	defer func(n uint) { MaxDecimalDigits = n }(MaxDecimalDigits)
+	MaxDecimalDigits = 200
+	x, y, z := MustParseDecimal("1234567.89"), MustParseDecimal("1.0837"), new(Decimal)
+	for i := 0; i < b.N; i++ {
+		z.Copy(x).Quo(y)
+	}
 }
@@ -0,0 +1,83 @@
+package big
+
+import (
+	"encoding/binary"
+
+	"github.com/golang-plus/errors"
+)
+
+var (
+	// ErrNaN is returned when decoding a NaN which can not be represented by Decimal.
+	ErrNaN = errors.New("NaN can not be represented by decimal")
+	// ErrInfinity is returned when decoding an infinity which can not be represented by Decimal.
+	ErrInfinity = errors.New("infinity can not be represented by decimal")
+)
+
+const (
+	// version of the binary format produced by MarshalBinary.
+	_DecimalBinaryVersion = byte(1)
+)
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return d.AppendText(nil), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	return setDecimal(d, text, defaultParseOptions())
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The layout is: 1 byte header (version << 1 | sign bit), the exponent as a zig-zag varint and the big-endian bytes of the absolute coefficient.
+func (d Decimal) MarshalBinary() ([]byte, error) {
+	scratch := getInt()
+	defer putInt(scratch)
+	c := d.coefficient(scratch)
+	header := _DecimalBinaryVersion << 1
+	if c.Sign() < 0 {
+		header |= 1
+	}
+	coefficient := c.Bytes() // absolute value
+	buf := make([]byte, 1+binary.MaxVarintLen64+len(coefficient))
+	buf[0] = header
+	n := 1 + binary.PutVarint(buf[1:], int64(d.exponent))
+	n += copy(buf[n:], coefficient)
+	return buf[:n], nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// The limits of DecimalLimits are enforced, d is unchanged if an error is returned.
+func (d *Decimal) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("decimal binary data is empty")
+	}
+	if version := data[0] >> 1; version != _DecimalBinaryVersion {
+		return errors.Newf("decimal binary version %d is unsupported", version)
+	}
+	exp, n := binary.Varint(data[1:])
+	if n <= 0 || int64(int(exp)) != exp {
+		return errors.New("decimal binary exponent is invalid")
+	}
+	c := getInt()
+	defer putInt(c)
+	c.SetBytes(data[1+n:])
+	if data[0]&1 == 1 {
+		c.Neg(c)
+	}
+	if err := DecimalLimits.check(c, int(exp)); err != nil {
+		return err
+	}
+	d.Copy(&Decimal{integer: c, exponent: int(exp)})
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+func (d Decimal) GobEncode() ([]byte, error) {
+	return d.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (d *Decimal) GobDecode(data []byte) error {
+	return d.UnmarshalBinary(data)
+}
@@ -0,0 +1,83 @@
+package big
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+func TestEncoding(t *testing.T) {
+	data := []string{
+		"0",
+		"1",
+		"-1",
+		"19.99",
+		"-0.000000000000000000000000000001",
+		"123456789012345678901234567890.123456789",
+		"1e300",
+		"-1e-300",
+	}
+
+	// test MarshalText UnmarshalText
+	for _, v := range data {
+		text, err := MustParseDecimal(v).MarshalText()
+		testing2.AssertEqual(t, err, nil)
+		d := new(Decimal)
+		testing2.AssertEqual(t, d.UnmarshalText(text), nil)
+		testing2.AssertEqual(t, d.Cmp(MustParseDecimal(v)), 0)
+	}
+	testing2.AssertNotEqual(t, new(Decimal).UnmarshalText([]byte("1.2.3")), nil)
+
+	// test MarshalBinary UnmarshalBinary
+	for _, v := range data {
+		b, err := MustParseDecimal(v).MarshalBinary()
+		testing2.AssertEqual(t, err, nil)
+		d := new(Decimal)
+		testing2.AssertEqual(t, d.UnmarshalBinary(b), nil)
+		testing2.AssertEqual(t, d.String(), MustParseDecimal(v).String())
+	}
+	b, _ := MustParseDecimal("-19.99").MarshalBinary()
+	testing2.AssertEqual(t, b, []byte{0x03, 0x03, 0x07, 0xcf}) // header, exponent -2, coefficient 1999
+	for _, b := range [][]byte{nil, {0x04}, {0x02}, {0x02, 0x80}} {
+		testing2.AssertNotEqual(t, new(Decimal).UnmarshalBinary(b), nil)
+	}
+
+	// test GobEncode GobDecode
+	type Order struct {
+		Price    Decimal
+		Quantity *Decimal
+	}
+	var buf bytes.Buffer
+	in := Order{Price: *MustParseDecimal("123456789.123456789"), Quantity: MustParseDecimal("-0.5")}
+	testing2.AssertEqual(t, gob.NewEncoder(&buf).Encode(in), nil)
+	var out Order
+	testing2.AssertEqual(t, gob.NewDecoder(&buf).Decode(&out), nil)
+	testing2.AssertEqual(t, out.Price.String(), "123456789.123456789")
+	testing2.AssertEqual(t, out.Quantity.String(), "-0.5")
+
+	// test JSON map keys
+	text, err := json.Marshal(map[*Decimal]int{MustParseDecimal("1.5"): 1})
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, string(text), `{"1.5":1}`)
+	var m map[*Decimal]int
+	testing2.AssertEqual(t, json.Unmarshal([]byte(`{"-2.25":2}`), &m), nil)
+	for k, v := range m {
+		testing2.AssertEqual(t, k.String(), "-2.25")
+		testing2.AssertEqual(t, v, 2)
+	}
+
+	// test XML attributes
+	type Item struct {
+		Price Decimal `xml:"price,attr"`
+	}
+	text, err = xml.Marshal(Item{Price: *MustParseDecimal("0.001")})
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, string(text), `<Item price="0.001"></Item>`)
+	var item Item
+	testing2.AssertEqual(t, xml.Unmarshal([]byte(`<Item price="-42.42"></Item>`), &item), nil)
+	testing2.AssertEqual(t, item.Price.String(), "-42.42")
+}
@@ -0,0 +1,298 @@
+package big
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/golang-plus/errors"
+)
+
+// Scale represents the scale (the number of digits after the decimal point, 0 to 18) of fixed-point types.
+// An out of range scale is reported as an error by the conversions from decimals and by Mul and Quo.
+// A scale is declared as an empty struct type, e.g.
+//
+//	type Scale3 struct{}
+//
+//	func (Scale3) Digits() int { return 3 }
+type Scale interface {
+	Digits() int
+}
+
+// The common scales.
+type (
+	Scale0 struct{} // integers
+	Scale2 struct{} // cents
+	Scale4 struct{} // basis points (1e-4 units)
+	Scale6 struct{} // micro units
+	Scale8 struct{} // 1e-8 units (e.g. satoshis)
+)
+
+func (Scale0) Digits() int { return 0 }
+func (Scale2) Digits() int { return 2 }
+func (Scale4) Digits() int { return 4 }
+func (Scale6) Digits() int { return 6 }
+func (Scale8) Digits() int { return 8 }
+
+var (
+	// ErrFixedOverflow is returned when a fixed-point value overflows its units.
+	ErrFixedOverflow = errors.New("fixed-point value overflows")
+)
+
+// Fixed represents a fixed-point decimal with the scale S: units × 10**-S, where units is an int64.
+// The zero value is zero. Fixed is a value type which can be compared by == and used as map key.
+// See Fixed128 for values beyond the range of int64 units.
+type Fixed[S Scale] struct {
+	units int64
+}
+
+// scaleOf returns the digits of the scale S, an error is returned if it is out of range.
+func scaleOf[S Scale]() (int, error) {
+	var s S
+	digits := s.Digits()
+	if digits < 0 || digits > 18 {
+		return 0, errors.Newf("scale %d of fixed-point type is out of range [0, 18]", digits)
+	}
+	return digits, nil
+}
+
+// NewFixed returns a new fixed-point decimal of units × 10**-S (e.g. NewFixed[Scale2](150) is 1.50).
+func NewFixed[S Scale](units int64) Fixed[S] {
+	return Fixed[S]{units: units}
+}
+
+// FixedFromDecimal returns the fixed-point decimal of d without rounding.
+// An error is returned if d has more decimal digits than S, ErrFixedOverflow is returned if d overflows.
+func FixedFromDecimal[S Scale](d *Decimal) (Fixed[S], error) {
+	scale, err := scaleOf[S]()
+	if err != nil {
+		return Fixed[S]{}, err
+	}
+	if d.isInline() {
+		if shift := d.exponent + scale; shift >= 0 {
+			if v, ok := mulPow10Int64(d.small, shift); ok {
+				return Fixed[S]{units: v}, nil
+			}
+		} else if shift >= -18 && d.small%int64(_Pow10Uint64[shift*-1]) == 0 {
+			return Fixed[S]{units: d.small / int64(_Pow10Uint64[shift*-1])}, nil
+		}
+	}
+	z, exact, fits := d.scaledIntegerWithin(19, scale)
+	if !exact {
+		return Fixed[S]{}, errors.Newf("decimal has more than %d decimal digits", scale)
+	}
+	if !fits || !z.IsInt64() {
+		return Fixed[S]{}, ErrFixedOverflow
+	}
+	return Fixed[S]{units: z.Int64()}, nil
+}
+
+// RoundFixed returns the fixed-point decimal of d rounded to the scale S with given rounding mode.
+// ErrFixedOverflow is returned if the rounded value overflows. d is not changed.
+func RoundFixed[S Scale](d *Decimal, mode RoundingMode) (Fixed[S], error) {
+	scale, err := scaleOf[S]()
+	if err != nil {
+		return Fixed[S]{}, err
+	}
+	var x Decimal
+	x.Copy(d).roundTo(scale*-1, mode)
+	return FixedFromDecimal[S](&x)
+}
+
+// ParseFixed returns a new fixed-point decimal by parsing str (as ParseDecimal) without rounding.
+func ParseFixed[S Scale](str string) (Fixed[S], error) {
+	var d Decimal
+	if err := setDecimal(&d, str, defaultParseOptions()); err != nil {
+		return Fixed[S]{}, err
+	}
+	return FixedFromDecimal[S](&d)
+}
+
+// Units returns the units of x (x × 10**S).
+func (x Fixed[S]) Units() int64 {
+	return x.units
+}
+
+// Scale returns the scale S of x.
+func (x Fixed[S]) Scale() int {
+	var s S
+	return s.Digits()
+}
+
+// decimal returns the decimal value of x (inline).
+func (x Fixed[S]) decimal() Decimal {
+	return Decimal{small: x.units, inline: true, exponent: x.Scale() * -1}
+}
+
+// Decimal returns a new decimal of x (lossless).
+func (x Fixed[S]) Decimal() *Decimal {
+	d := x.decimal()
+	return &d
+}
+
+// String converts x to a string (as Decimal.String).
+func (x Fixed[S]) String() string {
+	return string(x.AppendText(nil))
+}
+
+// AppendText appends the string form of x (as Decimal.AppendText) to dst and returns the extended buffer.
+func (x Fixed[S]) AppendText(dst []byte) []byte {
+	d := x.decimal()
+	return d.AppendText(dst)
+}
+
+// AppendFormat appends the string form of x (as Decimal.AppendFormat) to dst and returns the extended buffer.
+func (x Fixed[S]) AppendFormat(dst []byte, fmt byte, prec int) []byte {
+	d := x.decimal()
+	return d.AppendFormat(dst, fmt, prec)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (x Fixed[S]) MarshalText() ([]byte, error) {
+	return x.AppendText(nil), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, the text must not have more decimal digits than S.
+func (x *Fixed[S]) UnmarshalText(text []byte) error {
+	var d Decimal
+	if err := setDecimal(&d, text, defaultParseOptions()); err != nil {
+		return err
+	}
+	z, err := FixedFromDecimal[S](&d)
+	if err != nil {
+		return err
+	}
+	*x = z
+	return nil
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of x.
+func (x Fixed[S]) Sign() int {
+	switch {
+	case x.units < 0:
+		return -1
+	case x.units > 0:
+		return 1
+	}
+	return 0
+}
+
+// IsZero reports whether the value of x is equal to zero.
+func (x Fixed[S]) IsZero() bool {
+	return x.units == 0
+}
+
+// Cmp compares x and y and returns -1 if x < y, 0 if x == y or +1 if x > y.
+func (x Fixed[S]) Cmp(y Fixed[S]) int {
+	switch {
+	case x.units < y.units:
+		return -1
+	case x.units > y.units:
+		return 1
+	}
+	return 0
+}
+
+// Neg returns -x, ErrFixedOverflow is returned if x is the minimum value.
+func (x Fixed[S]) Neg() (Fixed[S], error) {
+	if x.units == math.MinInt64 {
+		return x, ErrFixedOverflow
+	}
+	return Fixed[S]{units: x.units * -1}, nil
+}
+
+// Add returns the sum x+y, ErrFixedOverflow is returned if it overflows.
+func (x Fixed[S]) Add(y Fixed[S]) (Fixed[S], error) {
+	z := x.units + y.units
+	if (z > x.units) != (y.units > 0) {
+		return x, ErrFixedOverflow
+	}
+	return Fixed[S]{units: z}, nil
+}
+
+// Sub returns the difference x-y, ErrFixedOverflow is returned if it overflows.
+func (x Fixed[S]) Sub(y Fixed[S]) (Fixed[S], error) {
+	z := x.units - y.units
+	if (z < x.units) != (y.units > 0) {
+		return x, ErrFixedOverflow
+	}
+	return Fixed[S]{units: z}, nil
+}
+
+// Mul returns the product x*y rounded to the scale S with given rounding mode, ErrFixedOverflow is returned if it overflows.
+func (x Fixed[S]) Mul(y Fixed[S], mode RoundingMode) (Fixed[S], error) {
+	scale, err := scaleOf[S]()
+	if err != nil {
+		return x, err
+	}
+	hi, lo := bits.Mul64(absUint64(x.units), absUint64(y.units))
+	z, ok := quoRound128(hi, lo, _Pow10Uint64[scale], (x.units < 0) != (y.units < 0), mode)
+	if !ok {
+		return x, ErrFixedOverflow
+	}
+	return Fixed[S]{units: z}, nil
+}
+
+// Quo returns the quotient x/y rounded to the scale S with given rounding mode.
+// ErrFixedOverflow is returned if it overflows, an error is returned if y is zero.
+func (x Fixed[S]) Quo(y Fixed[S], mode RoundingMode) (Fixed[S], error) {
+	scale, err := scaleOf[S]()
+	if err != nil {
+		return x, err
+	}
+	if y.units == 0 {
+		return x, errors.New("fixed-point division by zero")
+	}
+	hi, lo := bits.Mul64(absUint64(x.units), _Pow10Uint64[scale])
+	z, ok := quoRound128(hi, lo, absUint64(y.units), (x.units < 0) != (y.units < 0), mode)
+	if !ok {
+		return x, ErrFixedOverflow
+	}
+	return Fixed[S]{units: z}, nil
+}
+
+// Round returns x rounded to given precision (less than S) with given rounding mode, ErrFixedOverflow is returned if it overflows.
+func (x Fixed[S]) Round(precision uint, mode RoundingMode) (Fixed[S], error) {
+	d := x.decimal()
+	return RoundFixed[S](d.round(precision, mode), ToZero)
+}
+
+func absUint64(x int64) uint64 {
+	if x < 0 {
+		return -uint64(x) // also correct for math.MinInt64
+	}
+	return uint64(x)
+}
+
+// quoRound128 returns the signed quotient of the 128 bits magnitude (hi, lo) divided by y, rounded with given rounding mode,
+// and a boolean indicating whether it fits in int64.
+func quoRound128(hi, lo, y uint64, negative bool, mode RoundingMode) (int64, bool) {
+	if hi >= y { // quotient overflows 64 bits
+		return 0, false
+	}
+	q, r := bits.Div64(hi, lo, y)
+	class := discardedZero
+	switch {
+	case r == 0:
+	case r < y-r:
+		class = discardedBelowHalf
+	case r == y-r:
+		class = discardedHalf
+	default:
+		class = discardedAboveHalf
+	}
+	if mode.roundUp(class, q%2 != 0) {
+		if q++; q == 0 {
+			return 0, false
+		}
+	}
+	if negative {
+		if q > 1<<63 {
+			return 0, false
+		}
+		return int64(-q), true // also correct for 1<<63
+	}
+	if q > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(q), true
+}
@@ -0,0 +1,370 @@
+package big
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+	"math/bits"
+
+	"github.com/golang-plus/errors"
+)
+
+// Fixed128 represents a fixed-point decimal with the scale S: units × 10**-S, where units is a 128 bits two's complement integer
+// (about ±1.7e38). The zero value is zero. Fixed128 is a value type which can be compared by == and used as map key.
+// The arithmetic does not allocate, except Quo by a divisor beyond 64 bits.
+type Fixed128[S Scale] struct {
+	hi int64
+	lo uint64
+}
+
+// neg128 returns the two's complement negation of (hi, lo).
+func neg128(hi int64, lo uint64) (int64, uint64) {
+	lo, borrow := bits.Sub64(0, lo, 0)
+	h, _ := bits.Sub64(0, uint64(hi), borrow)
+	return int64(h), lo
+}
+
+// abs128 returns the magnitude of (hi, lo) (also correct for the minimum value).
+func abs128(hi int64, lo uint64) (uint64, uint64) {
+	if hi < 0 {
+		hi, lo = neg128(hi, lo)
+	}
+	return uint64(hi), lo
+}
+
+// signed128 returns the signed value of the magnitude (hi, lo) and a boolean indicating whether it fits in 128 bits.
+func signed128(hi, lo uint64, negative bool) (int64, uint64, bool) {
+	if negative {
+		if hi > 1<<63 || hi == 1<<63 && lo != 0 {
+			return 0, 0, false
+		}
+		h, l := neg128(int64(hi), lo)
+		return h, l, true // also correct for -1<<127
+	}
+	if hi >= 1<<63 {
+		return 0, 0, false
+	}
+	return int64(hi), lo, true
+}
+
+// round128 returns the signed value of the quotient magnitude (hi, lo) with the remainder r of the divisor y, rounded with given rounding mode,
+// and a boolean indicating whether it fits in 128 bits.
+func round128(hi, lo, r, y uint64, negative bool, mode RoundingMode) (int64, uint64, bool) {
+	class := discardedZero
+	switch {
+	case r == 0:
+	case r < y-r:
+		class = discardedBelowHalf
+	case r == y-r:
+		class = discardedHalf
+	default:
+		class = discardedAboveHalf
+	}
+	if mode.roundUp(class, lo%2 != 0) {
+		var carry uint64
+		lo, carry = bits.Add64(lo, 1, 0)
+		if hi, carry = bits.Add64(hi, 0, carry); carry != 0 {
+			return 0, 0, false
+		}
+	}
+	return signed128(hi, lo, negative)
+}
+
+// NewFixed128 returns a new fixed-point decimal of units × 10**-S (e.g. NewFixed128[Scale2](150) is 1.50).
+func NewFixed128[S Scale](units int64) Fixed128[S] {
+	return Fixed128[S]{hi: units >> 63, lo: uint64(units)}
+}
+
+// fixed128FromInt returns the fixed-point decimal of the units z, ErrFixedOverflow is returned if z overflows 128 bits.
+func fixed128FromInt[S Scale](z *big.Int) (Fixed128[S], error) {
+	if z.BitLen() > 128 {
+		return Fixed128[S]{}, ErrFixedOverflow
+	}
+	var buf [16]byte
+	z.FillBytes(buf[:]) // absolute value
+	hi, lo, ok := signed128(binary.BigEndian.Uint64(buf[:8]), binary.BigEndian.Uint64(buf[8:]), z.Sign() < 0)
+	if !ok {
+		return Fixed128[S]{}, ErrFixedOverflow
+	}
+	return Fixed128[S]{hi: hi, lo: lo}, nil
+}
+
+// Fixed128FromDecimal returns the fixed-point decimal of d without rounding.
+// An error is returned if d has more decimal digits than S, ErrFixedOverflow is returned if d overflows.
+func Fixed128FromDecimal[S Scale](d *Decimal) (Fixed128[S], error) {
+	scale, err := scaleOf[S]()
+	if err != nil {
+		return Fixed128[S]{}, err
+	}
+	if d.isInline() {
+		if shift := d.exponent + scale; shift >= 0 && shift <= 19 {
+			hi, lo := bits.Mul64(absUint64(d.small), _Pow10Uint64[shift]) // less than 2**127
+			h, l, _ := signed128(hi, lo, d.small < 0)
+			return Fixed128[S]{hi: h, lo: l}, nil
+		} else if shift < 0 && shift >= -18 && d.small%int64(_Pow10Uint64[shift*-1]) == 0 {
+			return NewFixed128[S](d.small / int64(_Pow10Uint64[shift*-1])), nil
+		}
+	}
+	z, exact, fits := d.scaledIntegerWithin(39, scale)
+	if !exact {
+		return Fixed128[S]{}, errors.Newf("decimal has more than %d decimal digits", scale)
+	}
+	if !fits {
+		return Fixed128[S]{}, ErrFixedOverflow
+	}
+	return fixed128FromInt[S](z)
+}
+
+// RoundFixed128 returns the fixed-point decimal of d rounded to the scale S with given rounding mode.
+// ErrFixedOverflow is returned if the rounded value overflows. d is not changed.
+func RoundFixed128[S Scale](d *Decimal, mode RoundingMode) (Fixed128[S], error) {
+	scale, err := scaleOf[S]()
+	if err != nil {
+		return Fixed128[S]{}, err
+	}
+	var x Decimal
+	x.Copy(d).roundTo(scale*-1, mode)
+	return Fixed128FromDecimal[S](&x)
+}
+
+// ParseFixed128 returns a new fixed-point decimal by parsing str (as ParseDecimal) without rounding.
+func ParseFixed128[S Scale](str string) (Fixed128[S], error) {
+	var d Decimal
+	if err := setDecimal(&d, str, defaultParseOptions()); err != nil {
+		return Fixed128[S]{}, err
+	}
+	return Fixed128FromDecimal[S](&d)
+}
+
+// Fixed128 returns the 128 bits fixed-point decimal of x (lossless).
+func (x Fixed[S]) Fixed128() Fixed128[S] {
+	return NewFixed128[S](x.units)
+}
+
+// Fixed returns the 64 bits fixed-point decimal of x, ErrFixedOverflow is returned if x overflows int64 units.
+func (x Fixed128[S]) Fixed() (Fixed[S], error) {
+	if x.hi != int64(x.lo)>>63 {
+		return Fixed[S]{}, ErrFixedOverflow
+	}
+	return Fixed[S]{units: int64(x.lo)}, nil
+}
+
+// Units returns the units of x (x × 10**S) as the high and low 64 bits of a 128 bits two's complement integer.
+func (x Fixed128[S]) Units() (hi int64, lo uint64) {
+	return x.hi, x.lo
+}
+
+// Scale returns the scale S of x.
+func (x Fixed128[S]) Scale() int {
+	var s S
+	return s.Digits()
+}
+
+// bigUnits sets z to the units of x and returns z.
+func (x Fixed128[S]) bigUnits(z *big.Int) *big.Int {
+	hi, lo := abs128(x.hi, x.lo)
+	var buf [16]byte
+	binary.BigEndian.PutUint64(buf[:8], hi)
+	binary.BigEndian.PutUint64(buf[8:], lo)
+	z.SetBytes(buf[:])
+	if x.hi < 0 {
+		z.Neg(z)
+	}
+	return z
+}
+
+// decimal returns the decimal value of x (inline if the units fit in int64).
+func (x Fixed128[S]) decimal() Decimal {
+	if x.hi == int64(x.lo)>>63 {
+		return Decimal{small: int64(x.lo), inline: true, exponent: x.Scale() * -1}
+	}
+	return Decimal{integer: x.bigUnits(new(big.Int)), exponent: x.Scale() * -1}
+}
+
+// Decimal returns a new decimal of x (lossless).
+func (x Fixed128[S]) Decimal() *Decimal {
+	d := x.decimal()
+	return &d
+}
+
+// String converts x to a string (as Decimal.String).
+func (x Fixed128[S]) String() string {
+	return string(x.AppendText(nil))
+}
+
+// AppendText appends the string form of x (as Decimal.AppendText) to dst and returns the extended buffer.
+func (x Fixed128[S]) AppendText(dst []byte) []byte {
+	d := x.decimal()
+	return d.AppendText(dst)
+}
+
+// AppendFormat appends the string form of x (as Decimal.AppendFormat) to dst and returns the extended buffer.
+func (x Fixed128[S]) AppendFormat(dst []byte, fmt byte, prec int) []byte {
+	d := x.decimal()
+	return d.AppendFormat(dst, fmt, prec)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (x Fixed128[S]) MarshalText() ([]byte, error) {
+	return x.AppendText(nil), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, the text must not have more decimal digits than S.
+func (x *Fixed128[S]) UnmarshalText(text []byte) error {
+	var d Decimal
+	if err := setDecimal(&d, text, defaultParseOptions()); err != nil {
+		return err
+	}
+	z, err := Fixed128FromDecimal[S](&d)
+	if err != nil {
+		return err
+	}
+	*x = z
+	return nil
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of x.
+func (x Fixed128[S]) Sign() int {
+	switch {
+	case x.hi < 0:
+		return -1
+	case x.hi > 0 || x.lo > 0:
+		return 1
+	}
+	return 0
+}
+
+// IsZero reports whether the value of x is equal to zero.
+func (x Fixed128[S]) IsZero() bool {
+	return x.hi == 0 && x.lo == 0
+}
+
+// Cmp compares x and y and returns -1 if x < y, 0 if x == y or +1 if x > y.
+func (x Fixed128[S]) Cmp(y Fixed128[S]) int {
+	switch {
+	case x.hi < y.hi || x.hi == y.hi && x.lo < y.lo:
+		return -1
+	case x.hi > y.hi || x.hi == y.hi && x.lo > y.lo:
+		return 1
+	}
+	return 0
+}
+
+// Neg returns -x, ErrFixedOverflow is returned if x is the minimum value.
+func (x Fixed128[S]) Neg() (Fixed128[S], error) {
+	if x.hi == math.MinInt64 && x.lo == 0 {
+		return x, ErrFixedOverflow
+	}
+	hi, lo := neg128(x.hi, x.lo)
+	return Fixed128[S]{hi: hi, lo: lo}, nil
+}
+
+// Add returns the sum x+y, ErrFixedOverflow is returned if it overflows.
+func (x Fixed128[S]) Add(y Fixed128[S]) (Fixed128[S], error) {
+	lo, carry := bits.Add64(x.lo, y.lo, 0)
+	h, _ := bits.Add64(uint64(x.hi), uint64(y.hi), carry)
+	if hi := int64(h); (x.hi < 0) == (y.hi < 0) && (hi < 0) != (x.hi < 0) {
+		return x, ErrFixedOverflow
+	}
+	return Fixed128[S]{hi: int64(h), lo: lo}, nil
+}
+
+// Sub returns the difference x-y, ErrFixedOverflow is returned if it overflows.
+func (x Fixed128[S]) Sub(y Fixed128[S]) (Fixed128[S], error) {
+	lo, borrow := bits.Sub64(x.lo, y.lo, 0)
+	h, _ := bits.Sub64(uint64(x.hi), uint64(y.hi), borrow)
+	if hi := int64(h); (x.hi < 0) != (y.hi < 0) && (hi < 0) != (x.hi < 0) {
+		return x, ErrFixedOverflow
+	}
+	return Fixed128[S]{hi: int64(h), lo: lo}, nil
+}
+
+// Mul returns the product x*y rounded to the scale S with given rounding mode, ErrFixedOverflow is returned if it overflows.
+func (x Fixed128[S]) Mul(y Fixed128[S], mode RoundingMode) (Fixed128[S], error) {
+	scale, err := scaleOf[S]()
+	if err != nil {
+		return x, err
+	}
+	xh, xl := abs128(x.hi, x.lo)
+	yh, yl := abs128(y.hi, y.lo)
+	// 256 bits product p3:p2:p1:p0
+	t1, p0 := bits.Mul64(xl, yl)
+	u1, u0 := bits.Mul64(xl, yh)
+	v1, v0 := bits.Mul64(xh, yl)
+	w1, w0 := bits.Mul64(xh, yh)
+	p1, c1 := bits.Add64(t1, u0, 0)
+	p1, c2 := bits.Add64(p1, v0, 0)
+	p2, c3 := bits.Add64(u1, v1, c1)
+	p2, c4 := bits.Add64(p2, w0, c2)
+	p3 := w1 + c3 + c4 // no overflow (the product is less than 2**256)
+	// divided by 10**S
+	p := _Pow10Uint64[scale]
+	q3, r := bits.Div64(0, p3, p)
+	q2, r := bits.Div64(r, p2, p)
+	q1, r := bits.Div64(r, p1, p)
+	q0, r := bits.Div64(r, p0, p)
+	if q3 != 0 || q2 != 0 {
+		return x, ErrFixedOverflow
+	}
+	hi, lo, ok := round128(q1, q0, r, p, (x.hi < 0) != (y.hi < 0), mode)
+	if !ok {
+		return x, ErrFixedOverflow
+	}
+	return Fixed128[S]{hi: hi, lo: lo}, nil
+}
+
+// Quo returns the quotient x/y rounded to the scale S with given rounding mode.
+// ErrFixedOverflow is returned if it overflows, an error is returned if y is zero.
+func (x Fixed128[S]) Quo(y Fixed128[S], mode RoundingMode) (Fixed128[S], error) {
+	scale, err := scaleOf[S]()
+	if err != nil {
+		return x, err
+	}
+	if y.IsZero() {
+		return x, errors.New("fixed-point division by zero")
+	}
+	negative := (x.hi < 0) != (y.hi < 0)
+	xh, xl := abs128(x.hi, x.lo)
+	yh, yl := abs128(y.hi, y.lo)
+	if yh == 0 { // 192 bits dividend n2:n1:n0 by 64 bits divisor
+		a1, n0 := bits.Mul64(xl, _Pow10Uint64[scale])
+		b1, b0 := bits.Mul64(xh, _Pow10Uint64[scale])
+		n1, carry := bits.Add64(a1, b0, 0)
+		n2 := b1 + carry
+		q2, r := bits.Div64(0, n2, yl)
+		q1, r := bits.Div64(r, n1, yl)
+		q0, r := bits.Div64(r, n0, yl)
+		if q2 != 0 {
+			return x, ErrFixedOverflow
+		}
+		hi, lo, ok := round128(q1, q0, r, yl, negative, mode)
+		if !ok {
+			return x, ErrFixedOverflow
+		}
+		return Fixed128[S]{hi: hi, lo: lo}, nil
+	}
+	n, d, r := getInt(), getInt(), getInt()
+	defer putInt(n)
+	defer putInt(d)
+	defer putInt(r)
+	n.Mul(x.bigUnits(n).Abs(n), pow10(scale))
+	d.Abs(y.bigUnits(d))
+	n.QuoRem(n, d, r)
+	class := discardedZero
+	if r.Sign() != 0 {
+		class = classify(r, d)
+	}
+	if mode.roundUp(class, n.Bit(0) == 1) {
+		n.Add(n, _IntOne)
+	}
+	if negative {
+		n.Neg(n)
+	}
+	return fixed128FromInt[S](n)
+}
+
+// Round returns x rounded to given precision (less than S) with given rounding mode, ErrFixedOverflow is returned if it overflows.
+func (x Fixed128[S]) Round(precision uint, mode RoundingMode) (Fixed128[S], error) {
+	d := x.decimal()
+	return RoundFixed128[S](d.round(precision, mode), ToZero)
+}
@@ -0,0 +1,152 @@
+package big
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+func TestFixed128(t *testing.T) {
+	// test conversions
+	x := NewFixed128[Scale2](-150)
+	hi, lo := x.Units()
+	testing2.AssertEqual(t, hi, int64(-1))
+	testing2.AssertEqual(t, lo, uint64(math.MaxUint64-149))
+	testing2.AssertEqual(t, x.Scale(), 2)
+	testing2.AssertEqual(t, x.String(), "-1.5")
+	testing2.AssertEqual(t, string(x.AppendFormat(nil, 'f', 2)), "-1.50")
+	max := "1701411834604692317316873037158841057.27" // (2**127-1) × 10**-2
+	min := "-1701411834604692317316873037158841057.28"
+	for _, v := range []string{"0", "1.5", "-0.01", "92233720368547758.08", "-92233720368547758.09", "1e30", "-123456789012345678901234567890.12", max, min} {
+		z, err := ParseFixed128[Scale2](v)
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, z.Decimal().Cmp(MustParseDecimal(v)), 0)
+		testing2.AssertEqual(t, z.String(), MustParseDecimal(v).String())
+		z2, err := Fixed128FromDecimal[Scale2](z.Decimal())
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, z2, z)
+	}
+	for _, v := range []string{"0.001", "1701411834604692317316873037158841057.28", "-1701411834604692317316873037158841057.29", "1e40", "12345678901234567890e-5"} {
+		_, err := ParseFixed128[Scale2](v)
+		testing2.AssertNotEqual(t, err, nil)
+	}
+	_, err := ParseFixed128[Scale2]("1e40")
+	testing2.AssertEqual(t, err, ErrFixedOverflow)
+	_, err = ParseFixed128[scale19]("1")
+	testing2.AssertNotEqual(t, err, nil)
+	f, err := NewFixed[Scale2](math.MinInt64).Fixed128().Fixed()
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, f.Units(), int64(math.MinInt64))
+	wide, _ := ParseFixed128[Scale2]("92233720368547758.08")
+	_, err = wide.Fixed()
+	testing2.AssertEqual(t, err, ErrFixedOverflow)
+
+	// test RoundFixed128
+	data := map[string][4]string{ // ToNearestEven, ToNearestAway, ToZero, AwayFromZero
+		"1.005":                              {"1", "1.01", "1", "1.01"},
+		"-1.0151":                            {"-1.02", "-1.02", "-1.01", "-1.02"},
+		"2.5e-10":                            {"0", "0", "0", "0.01"},
+		"123456789012345678901234567890.125": {"123456789012345678901234567890.12", "123456789012345678901234567890.13", "123456789012345678901234567890.12", "123456789012345678901234567890.13"},
+	}
+	for k, v := range data {
+		for i, mode := range []RoundingMode{ToNearestEven, ToNearestAway, ToZero, AwayFromZero} {
+			z, err := RoundFixed128[Scale2](MustParseDecimal(k), mode)
+			testing2.AssertEqual(t, err, nil)
+			testing2.AssertEqual(t, z.String(), v[i])
+		}
+	}
+	r, _ := NewFixed128[Scale4](12345).Round(2, ToNearestAway)
+	testing2.AssertEqual(t, r.String(), "1.23")
+
+	// test arithmetic against Decimal
+	defer func(n uint) { MaxDecimalDigits = n }(MaxDecimalDigits)
+	MaxDecimalDigits = 50 // enough to round the quotients
+	values := []string{
+		"0", "0.00000001", "-1", "7", "-333.33333333", "99999", "-987654321012.5", "92233720368.54775808",
+		"-1234567890123456789012345.6789", "99999999999999999999999999999.99999999", max, min,
+	}
+	for _, u := range values {
+		for _, v := range values {
+			x, _ := ParseFixed128[Scale8](u)
+			y, _ := ParseFixed128[Scale8](v)
+			z, err := x.Add(y)
+			want, err2 := Fixed128FromDecimal[Scale8](x.Decimal().Add(y.Decimal()))
+			testing2.AssertEqual(t, err, err2)
+			testing2.AssertEqual(t, z.Cmp(want) == 0 || err != nil, true)
+			z, err = x.Sub(y)
+			want, err2 = Fixed128FromDecimal[Scale8](x.Decimal().Sub(y.Decimal()))
+			testing2.AssertEqual(t, err, err2)
+			testing2.AssertEqual(t, z.Cmp(want) == 0 || err != nil, true)
+			testing2.AssertEqual(t, x.Cmp(y), x.Decimal().Cmp(y.Decimal()))
+			for _, mode := range []RoundingMode{ToNearestEven, ToNearestAway, ToZero, AwayFromZero} {
+				z, err := x.Mul(y, mode)
+				want, err2 := RoundFixed128[Scale8](x.Decimal().Mul(y.Decimal()), mode)
+				testing2.AssertEqual(t, err, err2)
+				if err == nil {
+					testing2.AssertEqual(t, z, want)
+				}
+				if !y.IsZero() {
+					z, err := x.Quo(y, mode)
+					want, err2 := RoundFixed128[Scale8](x.Decimal().Quo(y.Decimal()), mode)
+					testing2.AssertEqual(t, err, err2)
+					if err == nil {
+						testing2.AssertEqual(t, z, want)
+					}
+				}
+			}
+		}
+	}
+	_, err = NewFixed128[Scale2](1).Quo(NewFixed128[Scale2](0), ToZero)
+	testing2.AssertNotEqual(t, err, nil)
+
+	// test overflow
+	hi128, _ := ParseFixed128[Scale2](max)
+	lo128, _ := ParseFixed128[Scale2](min)
+	one := NewFixed128[Scale2](1)
+	_, err = hi128.Add(one)
+	testing2.AssertEqual(t, err, ErrFixedOverflow)
+	_, err = lo128.Sub(one)
+	testing2.AssertEqual(t, err, ErrFixedOverflow)
+	_, err = lo128.Neg()
+	testing2.AssertEqual(t, err, ErrFixedOverflow)
+	z, err := hi128.Neg()
+	testing2.AssertEqual(t, err, nil)
+	z, err = z.Sub(one)
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, z, lo128)
+	testing2.AssertEqual(t, lo128.Sign(), -1)
+	testing2.AssertEqual(t, hi128.Sign(), 1)
+	testing2.AssertEqual(t, NewFixed128[Scale2](0).IsZero(), true)
+	z, err = lo128.Mul(NewFixed128[Scale2](100), ToZero) // 1
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, z, lo128)
+	_, err = lo128.Mul(NewFixed128[Scale2](-100), ToZero)
+	testing2.AssertEqual(t, err, ErrFixedOverflow)
+	_, err = hi128.Quo(NewFixed128[Scale2](50), ToZero) // 0.5
+	testing2.AssertEqual(t, err, ErrFixedOverflow)
+
+	// test encoding
+	type payment struct {
+		Amount Fixed128[Scale2] `json:"amount"`
+	}
+	data2, err := json.Marshal(payment{Amount: NewFixed128[Scale2](-1999)})
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, string(data2), `{"amount":"-19.99"}`)
+	var p payment
+	testing2.AssertEqual(t, json.Unmarshal(data2, &p), nil)
+	testing2.AssertEqual(t, p.Amount, NewFixed128[Scale2](-1999))
+	testing2.AssertNotEqual(t, json.Unmarshal([]byte(`{"amount":"1.999"}`), &p), nil)
+	testing2.AssertEqual(t, p.Amount, NewFixed128[Scale2](-1999))
+
+	// test allocations
+	a, _ := ParseFixed128[Scale8]("-1234567890123456789012.5")
+	b, _ := ParseFixed128[Scale8]("3.25")
+	allocs := testing.AllocsPerRun(100, func() {
+		z, _ := a.Mul(b, ToNearestEven)
+		z, _ = z.Quo(b, ToNearestEven)
+		z, _ = z.Add(b)
+	})
+	testing2.AssertEqual(t, allocs, float64(0))
+}
@@ -0,0 +1,198 @@
+package big
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+type scale18 struct{}
+
+func (scale18) Digits() int { return 18 }
+
+type scale19 struct{} // out of range
+
+func (scale19) Digits() int { return 19 }
+
+func TestFixed(t *testing.T) {
+	// test conversions
+	x := NewFixed[Scale2](150)
+	testing2.AssertEqual(t, x.Units(), int64(150))
+	testing2.AssertEqual(t, x.Scale(), 2)
+	testing2.AssertEqual(t, x.String(), "1.5")
+	testing2.AssertEqual(t, string(x.AppendFormat(nil, 'f', 2)), "1.50")
+	testing2.AssertEqual(t, x.Decimal().Cmp(MustParseDecimal("1.5")), 0)
+	for _, v := range []string{"0", "1.5", "-0.01", "92233720368547758.07", "-92233720368547758.08", "1e10"} {
+		z, err := ParseFixed[Scale2](v)
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, z.Decimal().Cmp(MustParseDecimal(v)), 0)
+		z2, err := FixedFromDecimal[Scale2](z.Decimal())
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, z2, z)
+	}
+	for _, v := range []string{"0.001", "92233720368547758.08", "-92233720368547758.09", "1e30", "12345678901234567890e-5", "123456789012345678901234567890.1"} {
+		_, err := ParseFixed[Scale2](v)
+		testing2.AssertNotEqual(t, err, nil)
+	}
+	_, err := ParseFixed[Scale2]("1e30")
+	testing2.AssertEqual(t, err, ErrFixedOverflow)
+	s, err := ParseFixed[Scale8]("0.00000001")
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, s.Units(), int64(1))
+	testing2.AssertEqual(t, s.String(), "0.00000001")
+	testing2.AssertEqual(t, string(s.AppendFormat(nil, 'e', -1)), "1e-08")
+	f, err := ParseFixed[scale18]("-9.223372036854775808")
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, f.Units(), int64(math.MinInt64))
+	_, err = ParseFixed[Scale2]("1.999")
+	testing2.AssertEqual(t, err.Error(), "decimal has more than 2 decimal digits")
+
+	// test out of range scale (an error, no panic)
+	_, err = ParseFixed[scale19]("1")
+	testing2.AssertEqual(t, err.Error(), "scale 19 of fixed-point type is out of range [0, 18]")
+	_, err = RoundFixed[scale19](MustParseDecimal("1"), ToZero)
+	testing2.AssertNotEqual(t, err, nil)
+	_, err = NewFixed[scale19](1).Mul(NewFixed[scale19](1), ToZero)
+	testing2.AssertNotEqual(t, err, nil)
+	_, err = NewFixed[scale19](1).Quo(NewFixed[scale19](1), ToZero)
+	testing2.AssertNotEqual(t, err, nil)
+	testing2.AssertEqual(t, NewFixed[scale19](1).String(), "0.0000000000000000001")
+
+	// test RoundFixed
+	data := map[string][4]string{ // ToNearestEven, ToNearestAway, ToZero, AwayFromZero
+		"1.005":   {"1", "1.01", "1", "1.01"},
+		"1.015":   {"1.02", "1.02", "1.01", "1.02"},
+		"-1.0151": {"-1.02", "-1.02", "-1.01", "-1.02"},
+		"2.5e-10": {"0", "0", "0", "0.01"},
+		"1.25":    {"1.25", "1.25", "1.25", "1.25"},
+	}
+	for k, v := range data {
+		d := MustParseDecimal(k)
+		for i, mode := range []RoundingMode{ToNearestEven, ToNearestAway, ToZero, AwayFromZero} {
+			z, err := RoundFixed[Scale2](d, mode)
+			testing2.AssertEqual(t, err, nil)
+			testing2.AssertEqual(t, z.String(), v[i])
+		}
+		testing2.AssertEqual(t, d.Cmp(MustParseDecimal(k)), 0)
+	}
+	_, err = RoundFixed[Scale2](MustParseDecimal("92233720368547758.075"), ToNearestEven)
+	testing2.AssertEqual(t, err, ErrFixedOverflow)
+	r, _ := NewFixed[Scale4](12345).Round(2, ToNearestAway)
+	testing2.AssertEqual(t, r.String(), "1.23")
+	r, _ = NewFixed[Scale4](12355).Round(2, ToNearestEven)
+	testing2.AssertEqual(t, r.String(), "1.24")
+
+	// test arithmetic
+	a, b := NewFixed[Scale2](1050), NewFixed[Scale2](-325) // 10.5, -3.25
+	z, err := a.Add(b)
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, z.String(), "7.25")
+	z, err = a.Sub(b)
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, z.String(), "13.75")
+	z, err = a.Mul(b, ToNearestEven) // -34.125
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, z.String(), "-34.12")
+	z, _ = a.Mul(b, ToNearestAway)
+	testing2.AssertEqual(t, z.String(), "-34.13")
+	z, err = a.Quo(b, ToZero) // -3.230769...
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, z.String(), "-3.23")
+	z, _ = a.Quo(b, AwayFromZero)
+	testing2.AssertEqual(t, z.String(), "-3.24")
+	z, _ = NewFixed[Scale2](100).Quo(NewFixed[Scale2](800), ToNearestEven) // 0.125
+	testing2.AssertEqual(t, z.String(), "0.12")
+	_, err = a.Quo(NewFixed[Scale2](0), ToZero)
+	testing2.AssertNotEqual(t, err, nil)
+	testing2.AssertEqual(t, a.Cmp(b), 1)
+	testing2.AssertEqual(t, b.Cmp(a), -1)
+	testing2.AssertEqual(t, a.Cmp(NewFixed[Scale2](1050)), 0)
+	testing2.AssertEqual(t, b.Sign(), -1)
+	testing2.AssertEqual(t, NewFixed[Scale2](0).IsZero(), true)
+	z, _ = b.Neg()
+	testing2.AssertEqual(t, z.String(), "3.25")
+
+	// test arithmetic against Decimal
+	defer func(n uint) { MaxDecimalDigits = n }(MaxDecimalDigits)
+	MaxDecimalDigits = 50 // enough to round the quotients
+	values := []int64{0, 1, -1, 7, -333, 99999, 123456789, -987654321012, math.MaxInt64 / 3, math.MinInt64 / 7}
+	for _, u := range values {
+		for _, v := range values {
+			x, y := NewFixed[Scale8](u), NewFixed[Scale8](v)
+			for _, mode := range []RoundingMode{ToNearestEven, ToNearestAway, ToZero, AwayFromZero} {
+				z, err := x.Mul(y, mode)
+				want, err2 := RoundFixed[Scale8](x.Decimal().Mul(y.Decimal()), mode)
+				testing2.AssertEqual(t, err, err2)
+				if err == nil {
+					testing2.AssertEqual(t, z, want)
+				}
+				if v != 0 {
+					z, err := x.Quo(y, mode)
+					want, err2 := RoundFixed[Scale8](x.Decimal().Quo(y.Decimal()), mode)
+					testing2.AssertEqual(t, err, err2)
+					if err == nil {
+						testing2.AssertEqual(t, z, want)
+					}
+				}
+			}
+		}
+	}
+
+	// test overflow
+	max, min := NewFixed[Scale2](math.MaxInt64), NewFixed[Scale2](math.MinInt64)
+	one := NewFixed[Scale2](1)
+	_, err = max.Add(one)
+	testing2.AssertEqual(t, err, ErrFixedOverflow)
+	_, err = min.Sub(one)
+	testing2.AssertEqual(t, err, ErrFixedOverflow)
+	_, err = min.Add(NewFixed[Scale2](-1))
+	testing2.AssertEqual(t, err, ErrFixedOverflow)
+	z, err = max.Add(NewFixed[Scale2](-1))
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, z.Units(), int64(math.MaxInt64-1))
+	_, err = min.Neg()
+	testing2.AssertEqual(t, err, ErrFixedOverflow)
+	_, err = max.Mul(NewFixed[Scale2](101), ToZero) // 1.01
+	testing2.AssertEqual(t, err, ErrFixedOverflow)
+	z, err = min.Mul(NewFixed[Scale2](100), ToZero) // 1
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, z, min)
+	_, err = min.Mul(NewFixed[Scale2](-100), ToZero)
+	testing2.AssertEqual(t, err, ErrFixedOverflow)
+	_, err = max.Quo(NewFixed[Scale2](50), ToZero) // 0.5
+	testing2.AssertEqual(t, err, ErrFixedOverflow)
+	z, err = min.Quo(NewFixed[Scale2](100), ToZero)
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, z, min)
+
+	// test encoding
+	type payment struct {
+		Amount Fixed[Scale2] `json:"amount"`
+	}
+	data2, err := json.Marshal(payment{Amount: NewFixed[Scale2](-1999)})
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, string(data2), `{"amount":"-19.99"}`)
+	var p payment
+	testing2.AssertEqual(t, json.Unmarshal(data2, &p), nil)
+	testing2.AssertEqual(t, p.Amount.Units(), int64(-1999))
+	testing2.AssertNotEqual(t, json.Unmarshal([]byte(`{"amount":"1.999"}`), &p), nil)
+	testing2.AssertEqual(t, p.Amount.Units(), int64(-1999))
+
+	// test allocations
+	allocs := testing.AllocsPerRun(100, func() {
+		z, _ := a.Mul(b, ToNearestEven)
+		z, _ = z.Quo(b, ToNearestEven)
+		var buf [32]byte
+		z.AppendText(buf[:0])
+	})
+	testing2.AssertEqual(t, allocs, float64(0))
+}
+
+func BenchmarkFixedMul(b *testing.B) {
+	x, y := NewFixed[Scale8](123456789), NewFixed[Scale8](-987654321)
+	for i := 0; i < b.N; i++ {
+		x.Mul(y, ToNearestEven)
+	}
+}
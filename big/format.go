@@ -0,0 +1,175 @@
+package big
+
+import (
+	"strconv"
+)
+
+// digits returns the decimal digits of the coefficient of d without sign and trailing zeros (appended to buf[:0]),
+// and the position of the decimal point dp, so that |d| = 0.digits × 10**dp (an empty digits and zero dp for zero).
+func (d *Decimal) digits(buf []byte) ([]byte, int) {
+	if d.IsZero() {
+		return buf[:0], 0
+	}
+	if d.isInline() {
+		u := uint64(d.small)
+		if d.small < 0 {
+			u = -u
+		}
+		buf = strconv.AppendUint(buf[:0], u, 10)
+	} else {
+		buf = d.integer.Append(buf[:0], 10)
+		if buf[0] == '-' {
+			buf = buf[1:]
+		}
+	}
+	dp := len(buf) + d.exponent
+	for buf[len(buf)-1] == '0' {
+		buf = buf[:len(buf)-1]
+	}
+	return buf, dp
+}
+
+// AppendText appends the string form of d (as String) to dst and returns the extended buffer.
+// The exponential form (e.g. "1e+999999") is used if the exponent of d exceeds the limit of DecimalLimits,
+// so that the zeros padded to the digits are bounded.
+func (d *Decimal) AppendText(dst []byte) []byte {
+	if max := DecimalLimits.MaxExponent; max > 0 && (d.exponent > max || d.exponent < -max) {
+		return d.AppendFormat(dst, 'e', -1)
+	}
+	return d.AppendFormat(dst, 'f', -1)
+}
+
+// AppendFormat appends the string form of d, as generated by fmt and prec, to dst and returns the extended buffer.
+// The format fmt and precision prec follow the conventions of strconv.AppendFloat:
+// 'e' (-d.dddde±dd), 'E' (-d.ddddE±dd), 'f' (-ddd.dddd), 'g' ('e' for large exponents, 'f' otherwise) or 'G' ('E' or 'f').
+// The precision is the number of digits after the decimal point ('e', 'E', 'f') or the maximum number of significant digits ('g', 'G'),
+// -1 uses the smallest number of digits necessary to represent d exactly. The value is rounded to nearest, ties to even.
+// No allocation is made if the coefficient of d fits in int64 (and dst has the capacity).
+func (d *Decimal) AppendFormat(dst []byte, fmt byte, prec int) []byte {
+	var x Decimal
+	x.Copy(d)
+	var buf [24]byte
+	digs, dp := x.digits(buf[:])
+	shortest := prec < 0
+	if !shortest { // round to the precision
+		switch fmt {
+		case 'e', 'E':
+			x.roundTo(dp-1-prec, ToNearestEven)
+		case 'f':
+			x.roundTo(prec*-1, ToNearestEven)
+		case 'g', 'G':
+			if prec == 0 {
+				prec = 1
+			}
+			x.roundTo(dp-prec, ToNearestEven)
+		}
+		digs, dp = x.digits(buf[:])
+	}
+	negative := x.Sign() < 0
+
+	switch fmt {
+	case 'e', 'E':
+		if shortest {
+			prec = len(digs) - 1
+		}
+		return appendE(dst, negative, digs, dp, prec, fmt)
+	case 'f':
+		if shortest {
+			prec = len(digs) - dp
+		}
+		return appendF(dst, negative, digs, dp, prec)
+	case 'g', 'G':
+		if shortest {
+			prec = len(digs)
+		}
+		eprec := prec
+		if eprec > len(digs) && len(digs) >= dp {
+			eprec = len(digs)
+		}
+		if shortest { // %e is used if the exponent is less than -4 or greater than or equal to the precision (6 if shortest)
+			eprec = 6
+		}
+		if exp := dp - 1; exp < -4 || exp >= eprec {
+			if prec > len(digs) {
+				prec = len(digs)
+			}
+			return appendE(dst, negative, digs, dp, prec-1, fmt+'e'-'g')
+		}
+		if prec > dp {
+			prec = len(digs)
+		}
+		return appendF(dst, negative, digs, dp, prec-dp)
+	}
+	return append(dst, '%', fmt) // unknown format
+}
+
+// appendE appends 0.digs × 10**dp in the form of -d.dddde±dd with prec digits after the decimal point.
+func appendE(dst []byte, negative bool, digs []byte, dp int, prec int, fmt byte) []byte {
+	if negative {
+		dst = append(dst, '-')
+	}
+	ch := byte('0')
+	if len(digs) > 0 {
+		ch = digs[0]
+	}
+	dst = append(dst, ch)
+	if prec > 0 {
+		dst = append(dst, '.')
+		i := 1
+		if m := prec + 1; len(digs) > i {
+			if m > len(digs) {
+				m = len(digs)
+			}
+			dst = append(dst, digs[i:m]...)
+			i = m
+		}
+		for ; i <= prec; i++ {
+			dst = append(dst, '0')
+		}
+	}
+	dst = append(dst, fmt)
+	exp := dp - 1
+	if len(digs) == 0 { // zero
+		exp = 0
+	}
+	if exp < 0 {
+		dst = append(dst, '-')
+		exp = exp * -1
+	} else {
+		dst = append(dst, '+')
+	}
+	if exp < 10 { // at least 2 digits
+		dst = append(dst, '0')
+	}
+	return strconv.AppendInt(dst, int64(exp), 10)
+}
+
+// appendF appends 0.digs × 10**dp in the form of -ddd.dddd with prec (no decimal point if not positive) digits after the decimal point.
+func appendF(dst []byte, negative bool, digs []byte, dp int, prec int) []byte {
+	if negative {
+		dst = append(dst, '-')
+	}
+	if dp > 0 {
+		m := dp
+		if m > len(digs) {
+			m = len(digs)
+		}
+		dst = append(dst, digs[:m]...)
+		for ; m < dp; m++ {
+			dst = append(dst, '0')
+		}
+	} else {
+		dst = append(dst, '0')
+	}
+	if prec > 0 {
+		dst = append(dst, '.')
+		for i := 1; i <= prec; i++ {
+			ch := byte('0')
+			if j := dp + i - 1; 0 <= j && j < len(digs) {
+				ch = digs[j]
+			}
+			dst = append(dst, ch)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,97 @@
+package big
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+func TestFormat(t *testing.T) {
+	// test AppendText
+	for _, v := range []string{"0", "-1", "1000", "0.001", "-123.456", "123456789012345678901234567890.5", "-9223372036854775808"} {
+		testing2.AssertEqual(t, string(MustParseDecimal(v).AppendText([]byte("x="))), "x="+v)
+	}
+	testing2.AssertEqual(t, MustParseDecimal("1e3").String(), "1000")
+	testing2.AssertEqual(t, MustParseDecimal("-15e-3").String(), "-0.015")
+
+	// test AppendFormat with shortest precision
+	data := map[string][3]string{ // 'e', 'f', 'g'
+		"0":                                {"0e+00", "0", "0"},
+		"1":                                {"1e+00", "1", "1"},
+		"-123.456":                         {"-1.23456e+02", "-123.456", "-123.456"},
+		"0.0001":                           {"1e-04", "0.0001", "0.0001"},
+		"0.00001":                          {"1e-05", "0.00001", "1e-05"},
+		"100000":                           {"1e+05", "100000", "100000"},
+		"1000000":                          {"1e+06", "1000000", "1e+06"},
+		"1.5e300":                          {"1.5e+300", "15" + strings.Repeat("0", 299), "1.5e+300"},
+		"-1.2345e-7":                       {"-1.2345e-07", "-0.00000012345", "-1.2345e-07"},
+		"123456789012345678901234567890.5": {"1.234567890123456789012345678905e+29", "123456789012345678901234567890.5", "1.234567890123456789012345678905e+29"},
+	}
+	for k, v := range data {
+		for i, fmt := range []byte{'e', 'f', 'g'} {
+			testing2.AssertEqual(t, string(MustParseDecimal(k).AppendFormat(nil, fmt, -1)), v[i])
+		}
+	}
+
+	// test AppendFormat with precision
+	data2 := map[string][3]string{ // 'e', 'f', 'g' (precision 2)
+		"0":                                  {"0.00e+00", "0.00", "0"},
+		"1.005":                              {"1.00e+00", "1.00", "1"},
+		"1.015":                              {"1.02e+00", "1.02", "1"},
+		"-9.995":                             {"-1.00e+01", "-10.00", "-10"},
+		"99.5":                               {"9.95e+01", "99.50", "1e+02"},
+		"0.00125":                            {"1.25e-03", "0.00", "0.0012"},
+		"123456.789":                         {"1.23e+05", "123456.79", "1.2e+05"},
+		"123456789012345678901234567890.555": {"1.23e+29", "123456789012345678901234567890.56", "1.2e+29"},
+	}
+	for k, v := range data2 {
+		for i, fmt := range []byte{'e', 'f', 'g'} {
+			testing2.AssertEqual(t, string(MustParseDecimal(k).AppendFormat(nil, fmt, 2)), v[i])
+		}
+	}
+	testing2.AssertEqual(t, string(MustParseDecimal("-1234.5").AppendFormat(nil, 'E', 3)), "-1.234E+03")
+	testing2.AssertEqual(t, string(MustParseDecimal("1234.5").AppendFormat(nil, 'G', 3)), "1.23E+03")
+	testing2.AssertEqual(t, string(MustParseDecimal("1234.5").AppendFormat(nil, 'x', 3)), "%x")
+
+	// test AppendFormat against strconv.AppendFloat (with exact binary values)
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		f := math.Ldexp(float64(random.Int63n(1<<30)-1<<29), random.Intn(60)-20)
+		d := MustParseDecimal(strconv.FormatFloat(f, 'f', 40, 64))
+		for _, fmt := range []byte{'e', 'E', 'f', 'g', 'G'} {
+			for prec := 0; prec < 12; prec++ {
+				testing2.AssertEqual(t, string(d.AppendFormat(nil, fmt, prec)), strconv.FormatFloat(f, fmt, prec, 64))
+			}
+		}
+	}
+
+	// test allocations
+	d := MustParseDecimal("-1234567.8950")
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = d.AppendText(buf[:0])
+		buf = d.AppendFormat(buf, 'e', 3)
+		buf = d.AppendFormat(buf, 'f', 2)
+		buf = d.AppendFormat(buf, 'g', -1)
+	})
+	testing2.AssertEqual(t, allocs, float64(0))
+	testing2.AssertEqual(t, string(buf), "-1234567.895-1.235e+06-1234567.90-1.234567895e+06")
+	d = MustParseDecimal("12345678901234567890").Sub(MustParseDecimal("12345678901234567889.5")) // a big coefficient fitting in int64
+	allocs = testing.AllocsPerRun(100, func() {
+		buf = d.AppendText(buf[:0])
+	})
+	testing2.AssertEqual(t, allocs, float64(0))
+	testing2.AssertEqual(t, string(buf), "0.5")
+}
+
+func BenchmarkAppendText(b *testing.B) {
+	d := MustParseDecimal("-1234567.895")
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		buf = d.AppendText(buf[:0])
+	}
+}
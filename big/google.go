@@ -0,0 +1,58 @@
+package big
+
+import (
+	"math/big"
+
+	"github.com/golang-plus/errors"
+)
+
+// Conversions between Decimal and the fields of the well-known protobuf types google.type.Decimal (value string)
+// and google.type.Money (currency_code string, units int64, nanos int32), without depending on the protobuf runtime.
+
+const (
+	_NanosPerUnit = 1000000000
+)
+
+// ParseGoogleDecimal returns a new decimal by parsing the value field of google.type.Decimal.
+// Besides the forms accepted by ParseDecimal, a bare leading or trailing decimal point (".5", "5.") is accepted.
+func ParseGoogleDecimal(value string) (*Decimal, error) {
+	return ParseDecimalWith(value, ParseOptions{BareDot: true, Limits: DecimalLimits})
+}
+
+// GoogleDecimal returns the value field of google.type.Decimal for d (the normalized form without exponent).
+func (d *Decimal) GoogleDecimal() string {
+	return d.String()
+}
+
+// NewDecimalFromGoogleMoney returns a new decimal by the units and nanos fields of google.type.Money.
+// nanos must be in range [-999999999, +999999999] and have the same sign as units (if units is not zero).
+func NewDecimalFromGoogleMoney(units int64, nanos int32) (*Decimal, error) {
+	if nanos <= -_NanosPerUnit || nanos >= _NanosPerUnit {
+		return nil, errors.Newf("google.type.Money nanos %d is out of range", nanos)
+	}
+	if units > 0 && nanos < 0 || units < 0 && nanos > 0 {
+		return nil, errors.Newf("google.type.Money units %d and nanos %d have different signs", units, nanos)
+	}
+	d := new(Decimal)
+	d.integer = big.NewInt(units)
+	d.integer.Mul(d.integer, big.NewInt(_NanosPerUnit))
+	d.integer.Add(d.integer, big.NewInt(int64(nanos)))
+	d.exponent = -9
+	d.trimFraction()
+	return d, nil
+}
+
+// GoogleMoney returns the units and nanos fields of google.type.Money for d.
+// d is rounded (to nearest, ties to even) if it has more than 9 decimal digits.
+// An error is returned if the units overflows int64.
+func (d *Decimal) GoogleMoney() (int64, int32, error) {
+	nanos, _, fits := new(Decimal).Copy(d).RoundToNearestEven(9).scaledIntegerWithin(19+9, 9) // units has at most 19 digits
+	if !fits {
+		return 0, 0, errors.New("decimal overflows google.type.Money units")
+	}
+	units, r := nanos.QuoRem(nanos, big.NewInt(_NanosPerUnit), new(big.Int))
+	if !units.IsInt64() {
+		return 0, 0, errors.New("decimal overflows google.type.Money units")
+	}
+	return units.Int64(), int32(r.Int64()), nil
+}
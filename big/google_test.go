@@ -0,0 +1,72 @@
+package big
+
+import (
+	"math"
+	"testing"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+func TestGoogle(t *testing.T) {
+	// test ParseGoogleDecimal
+	data := map[string]string{
+		"2.5":      "2.5",
+		"+2.5":     "2.5",
+		"-2.5e-1":  "-0.25",
+		".5":       "0.5",
+		"-.5":      "-0.5",
+		"5.":       "5",
+		"1.50E+2":  "150",
+		"0001.100": "1.1",
+		"0":        "0",
+	}
+	for k, v := range data {
+		d, err := ParseGoogleDecimal(k)
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.GoogleDecimal(), v)
+	}
+	for _, v := range []string{"", ".", "-", "+.", "e5", "1e", "1.2.3", " 1", "NaN", "Infinity", "1_000"} {
+		_, err := ParseGoogleDecimal(v)
+		testing2.AssertNotEqual(t, err, nil)
+	}
+
+	// test NewDecimalFromGoogleMoney
+	data2 := map[string][2]int64{
+		"1.75":                  {1, 750000000},
+		"-1.75":                 {-1, -750000000},
+		"-0.75":                 {0, -750000000},
+		"0.000000001":           {0, 1},
+		"0":                     {0, 0},
+		"9223372036854775807.5": {math.MaxInt64, 500000000},
+	}
+	for k, v := range data2 {
+		d, err := NewDecimalFromGoogleMoney(v[0], int32(v[1]))
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.String(), k)
+		units, nanos, err := d.GoogleMoney()
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, units, v[0])
+		testing2.AssertEqual(t, nanos, int32(v[1]))
+	}
+	for _, v := range [][2]int64{{1, -1}, {-1, 1}, {0, 1000000000}, {0, -1000000000}} {
+		_, err := NewDecimalFromGoogleMoney(v[0], int32(v[1]))
+		testing2.AssertNotEqual(t, err, nil)
+	}
+
+	// test GoogleMoney rounding and overflow
+	data3 := map[string][2]int64{
+		"0.0000000015":  {0, 2},
+		"0.0000000025":  {0, 2},
+		"-1.9999999999": {-2, 0},
+		"12.3456789014": {12, 345678901},
+	}
+	for k, v := range data3 {
+		d := MustParseDecimal(k)
+		units, nanos, err := d.GoogleMoney()
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, [2]int64{units, int64(nanos)}, v)
+		testing2.AssertEqual(t, d.String(), k) // unchanged
+	}
+	_, _, err := MustParseDecimal("9223372036854775808").GoogleMoney()
+	testing2.AssertNotEqual(t, err, nil)
+}
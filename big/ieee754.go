@@ -0,0 +1,537 @@
+package big
+
+import (
+	"math/big"
+)
+
+// IEEE 754-2008 decimal interchange formats.
+//
+// Decimal32, Decimal64 and Decimal128 hold the Binary Integer Decimal (BID) encoding of the value,
+// the Densely Packed Decimal (DPD) encoding can be converted from and to by the DPD functions.
+// Conversions from Decimal round (to nearest, ties to even) when the decimal has more digits than the format allows,
+// and clamp the exponent (padding the coefficient with zeros) or overflow to infinity when the exponent exceeds the format.
+
+// ieeeFormat describes an IEEE 754-2008 decimal interchange format.
+type ieeeFormat struct {
+	bits      uint // total bits (k)
+	precision int  // decimal digits of the coefficient (p)
+	w         uint // exponent continuation bits (the combination field has w+5 bits)
+	t         uint // trailing significand bits
+	bias      int
+	qmin      int // minimal exponent of the coefficient
+	qmax      int // maximal exponent of the coefficient
+}
+
+var (
+	_Decimal32Format  = &ieeeFormat{bits: 32, precision: 7, w: 6, t: 20, bias: 101, qmin: -101, qmax: 90}
+	_Decimal64Format  = &ieeeFormat{bits: 64, precision: 16, w: 8, t: 50, bias: 398, qmin: -398, qmax: 369}
+	_Decimal128Format = &ieeeFormat{bits: 128, precision: 34, w: 12, t: 110, bias: 6176, qmin: -6176, qmax: 6111}
+)
+
+const (
+	_IEEEFinite = iota
+	_IEEEInfinity
+	_IEEENaN
+	_IEEESignalingNaN
+)
+
+// ieeeValue is the decoded value of an IEEE 754-2008 decimal.
+type ieeeValue struct {
+	kind        int
+	negative    bool
+	coefficient *big.Int // finite only
+	exponent    int      // finite only
+}
+
+// _DPDEncode maps 3 digits (0-999) to a declet, _DPDDecode maps a declet to 3 digits (non-canonical declets included).
+var (
+	_DPDEncode [1000]uint16
+	_DPDDecode [1024]uint16
+)
+
+func init() {
+	for n := 0; n < 1000; n++ {
+		d2, d1, d0 := uint16(n/100), uint16(n/10%10), uint16(n%10)
+		a, e, i := d2>>3, d1>>3, d0>>3 // the digits greater than 7
+		bcd, fgh, jkm := d2&7, d1&7, d0&7
+		d, h, m := d2&1, d1&1, d0&1
+		jk, fg := jkm>>1, fgh>>1
+		var declet uint16
+		switch a<<2 | e<<1 | i {
+		case 0: // 000
+			declet = bcd<<7 | fgh<<4 | jkm
+		case 1: // 001
+			declet = bcd<<7 | fgh<<4 | 0x8 | m
+		case 2: // 010
+			declet = bcd<<7 | jk<<5 | h<<4 | 0xA | m
+		case 4: // 100
+			declet = jk<<8 | d<<7 | fgh<<4 | 0xC | m
+		case 6: // 110
+			declet = jk<<8 | d<<7 | h<<4 | 0xE | m
+		case 5: // 101
+			declet = fg<<8 | d<<7 | 0x1<<5 | h<<4 | 0xE | m
+		case 3: // 011
+			declet = bcd<<7 | 0x2<<5 | h<<4 | 0xE | m
+		case 7: // 111
+			declet = d<<7 | 0x3<<5 | h<<4 | 0xE | m
+		}
+		_DPDEncode[n] = declet
+		_DPDDecode[declet] = uint16(n)
+	}
+	for declet := range _DPDDecode { // non-canonical declets (xx11x111x with xx != 00)
+		if declet&0x6E == 0x6E && declet&0x300 != 0 {
+			_DPDDecode[declet] = _DPDDecode[declet&^0x300]
+		}
+	}
+}
+
+// fromDecimal converts d to the IEEE value of format f and returns a boolean indicating whether is exact.
+func (f *ieeeFormat) fromDecimal(d *Decimal) (*ieeeValue, bool) {
+	c := d.coefficient(new(big.Int))
+	v := &ieeeValue{negative: c.Sign() < 0, coefficient: new(big.Int).Abs(c), exponent: d.exponent}
+	if v.coefficient.Sign() == 0 {
+		v.exponent = clampInt(v.exponent, f.qmin, f.qmax)
+		return v, true
+	}
+
+	exact := true
+	digits := len(v.coefficient.String())
+	drop := digits - f.precision
+	if f.qmin-v.exponent > drop {
+		drop = f.qmin - v.exponent
+	}
+	if drop > digits { // less than half of the smallest unit
+		v.coefficient.SetInt64(0)
+		v.exponent += drop
+		exact = false
+	} else if drop > 0 {
+		var inexact bool
+		v.coefficient, inexact = roundHalfEven(v.coefficient, drop)
+		v.exponent += drop
+		exact = !inexact
+		if v.coefficient.Cmp(pow10(f.precision)) == 0 {
+			v.coefficient.Quo(v.coefficient, big.NewInt(10))
+			v.exponent++
+		}
+	}
+	if v.exponent > f.qmax {
+		if v.coefficient.Sign() != 0 && len(v.coefficient.String())+v.exponent-f.qmax > f.precision { // overflow
+			return &ieeeValue{kind: _IEEEInfinity, negative: v.negative}, false
+		}
+		v.coefficient.Mul(v.coefficient, pow10(v.exponent-f.qmax))
+		v.exponent = f.qmax
+	}
+	return v, exact
+}
+
+// roundHalfEven returns x (non-negative) with the last n digits discarded (rounding to nearest, ties to even)
+// and a boolean indicating whether non-zero digits discarded.
+func roundHalfEven(x *big.Int, n int) (*big.Int, bool) {
+	divisor := pow10(n)
+	z, r := new(big.Int).QuoRem(x, divisor, new(big.Int))
+	if r.Sign() == 0 {
+		return z, false
+	}
+	if c := r.Lsh(r, 1).Cmp(divisor); c > 0 || c == 0 && z.Bit(0) == 1 {
+		z.Add(z, big.NewInt(1))
+	}
+	return z, true
+}
+
+func clampInt(x, min, max int) int {
+	if x < min {
+		return min
+	}
+	if x > max {
+		return max
+	}
+	return x
+}
+
+// toDecimal returns the decimal of v.
+func (v *ieeeValue) toDecimal() (*Decimal, error) {
+	switch v.kind {
+	case _IEEEInfinity:
+		return nil, ErrInfinity
+	case _IEEENaN, _IEEESignalingNaN:
+		return nil, ErrNaN
+	}
+	d := new(Decimal)
+	d.integer = new(big.Int).Set(v.coefficient)
+	if v.negative {
+		d.integer.Neg(d.integer)
+	}
+	d.exponent = v.exponent
+	d.trimFraction()
+	return d, nil
+}
+
+// special returns the bits of the special value v (infinity or NaN) of format f.
+func (f *ieeeFormat) special(v *ieeeValue) *big.Int {
+	var g int64
+	switch v.kind {
+	case _IEEEInfinity:
+		g = 0x3C // 11110 0
+	case _IEEENaN:
+		g = 0x3E // 11111 0
+	default:
+		g = 0x3F // 11111 1
+	}
+	z := big.NewInt(g)
+	if v.negative {
+		z.SetBit(z, 6, 1)
+	}
+	return z.Lsh(z, f.bits-7)
+}
+
+// decodeSpecial returns the special value (infinity or NaN) of bits z of format f, or nil if z is finite.
+func (f *ieeeFormat) decodeSpecial(z *big.Int) *ieeeValue {
+	g := new(big.Int).Rsh(z, f.bits-7).Uint64()
+	v := &ieeeValue{negative: g&0x40 != 0}
+	switch {
+	case g&0x3E == 0x3E:
+		v.kind = _IEEENaN
+		if g&0x01 != 0 {
+			v.kind = _IEEESignalingNaN
+		}
+	case g&0x3E == 0x3C:
+		v.kind = _IEEEInfinity
+	default:
+		return nil
+	}
+	return v
+}
+
+// bitsOf returns n bits of z from position pos (pos 0 is the least significant bit).
+func bitsOf(z *big.Int, pos, n uint) *big.Int {
+	x := new(big.Int).Rsh(z, pos)
+	return x.And(x, new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), n), big.NewInt(1)))
+}
+
+// encodeBID returns the BID encoding of v in format f.
+func (f *ieeeFormat) encodeBID(v *ieeeValue) *big.Int {
+	if v.kind != _IEEEFinite {
+		return f.special(v)
+	}
+	z := new(big.Int)
+	e := big.NewInt(int64(v.exponent + f.bias))
+	if v.coefficient.BitLen() <= int(f.t)+3 {
+		z.Lsh(e, f.t+3).Or(z, v.coefficient)
+	} else {
+		z.Lsh(big.NewInt(3), f.w+2).Or(z, e)             // 11 and exponent
+		z.Lsh(z, 1).Or(z, bitsOf(v.coefficient, f.t, 1)) // the bit after implicit 100
+		z.Lsh(z, f.t).Or(z, bitsOf(v.coefficient, 0, f.t))
+	}
+	if v.negative {
+		z.SetBit(z, int(f.bits-1), 1)
+	}
+	return z
+}
+
+// decodeBID returns the value of the BID encoding z in format f.
+func (f *ieeeFormat) decodeBID(z *big.Int) *ieeeValue {
+	if v := f.decodeSpecial(z); v != nil {
+		return v
+	}
+	v := &ieeeValue{negative: z.Bit(int(f.bits-1)) == 1}
+	if bitsOf(z, f.bits-3, 2).Uint64() != 3 {
+		v.exponent = int(bitsOf(z, f.t+3, f.w+2).Int64()) - f.bias
+		v.coefficient = bitsOf(z, 0, f.t+3)
+	} else {
+		v.exponent = int(bitsOf(z, f.t+1, f.w+2).Int64()) - f.bias
+		v.coefficient = bitsOf(z, 0, f.t+1)
+		v.coefficient.SetBit(v.coefficient, int(f.t+3), 1)
+	}
+	if v.coefficient.Cmp(pow10(f.precision)) >= 0 { // non-canonical coefficient is zero
+		v.coefficient.SetInt64(0)
+	}
+	return v
+}
+
+// encodeDPD returns the DPD encoding of v in format f.
+func (f *ieeeFormat) encodeDPD(v *ieeeValue) *big.Int {
+	if v.kind != _IEEEFinite {
+		return f.special(v)
+	}
+	declets := int(f.t / 10)
+	t := new(big.Int)
+	q, r := new(big.Int).Set(v.coefficient), new(big.Int)
+	thousand := big.NewInt(1000)
+	for i := 0; i < declets; i++ {
+		q.QuoRem(q, thousand, r)
+		t.Or(t, new(big.Int).Lsh(big.NewInt(int64(_DPDEncode[r.Int64()])), uint(i*10)))
+	}
+	d0 := q.Int64() // leading digit
+	e := int64(v.exponent + f.bias)
+	msb := e >> f.w
+	var g int64
+	if d0 < 8 {
+		g = msb<<3 | d0
+	} else {
+		g = 0x18 | msb<<1 | d0&1
+	}
+	z := big.NewInt(g)
+	z.Lsh(z, f.w).Or(z, big.NewInt(e&(1<<f.w-1)))
+	z.Lsh(z, f.t).Or(z, t)
+	if v.negative {
+		z.SetBit(z, int(f.bits-1), 1)
+	}
+	return z
+}
+
+// decodeDPD returns the value of the DPD encoding z in format f.
+func (f *ieeeFormat) decodeDPD(z *big.Int) *ieeeValue {
+	if v := f.decodeSpecial(z); v != nil {
+		return v
+	}
+	v := &ieeeValue{negative: z.Bit(int(f.bits-1)) == 1}
+	g := bitsOf(z, f.bits-6, 5).Int64()
+	var msb, d0 int64
+	if g>>3 != 3 {
+		msb, d0 = g>>3, g&7
+	} else {
+		msb, d0 = g>>1&3, 8|g&1
+	}
+	v.exponent = int(msb<<f.w|bitsOf(z, f.t, f.w).Int64()) - f.bias
+	v.coefficient = big.NewInt(d0)
+	thousand := big.NewInt(1000)
+	for i := int(f.t/10) - 1; i >= 0; i-- {
+		v.coefficient.Mul(v.coefficient, thousand)
+		v.coefficient.Add(v.coefficient, big.NewInt(int64(_DPDDecode[bitsOf(z, uint(i*10), 10).Int64()])))
+	}
+	return v
+}
+
+func (f *ieeeFormat) nan() *ieeeValue {
+	return &ieeeValue{kind: _IEEENaN}
+}
+
+func (f *ieeeFormat) inf(sign int) *ieeeValue {
+	return &ieeeValue{kind: _IEEEInfinity, negative: sign < 0}
+}
+
+func (v *ieeeValue) String() string {
+	switch v.kind {
+	case _IEEEInfinity:
+		if v.negative {
+			return "-Inf"
+		}
+		return "+Inf"
+	case _IEEENaN, _IEEESignalingNaN:
+		return "NaN"
+	}
+	d, _ := v.toDecimal()
+	return d.String()
+}
+
+func (v *ieeeValue) isInf(sign int) bool {
+	return v.kind == _IEEEInfinity && (sign >= 0 && !v.negative || sign <= 0 && v.negative)
+}
+
+func (v *ieeeValue) isNaN() bool {
+	return v.kind == _IEEENaN || v.kind == _IEEESignalingNaN
+}
+
+// Decimal32 represents an IEEE 754-2008 decimal32 value (7 digits) in BID encoding.
+type Decimal32 uint32
+
+// Decimal32 returns the decimal32 value nearest to d and a boolean indicating whether is exact.
+func (d *Decimal) Decimal32() (Decimal32, bool) {
+	v, exact := _Decimal32Format.fromDecimal(d)
+	return Decimal32(_Decimal32Format.encodeBID(v).Uint64()), exact
+}
+
+// Decimal32NaN returns a decimal32 NaN.
+func Decimal32NaN() Decimal32 {
+	return Decimal32(_Decimal32Format.encodeBID(_Decimal32Format.nan()).Uint64())
+}
+
+// Decimal32Inf returns positive infinity if sign >= 0, negative infinity if sign < 0.
+func Decimal32Inf(sign int) Decimal32 {
+	return Decimal32(_Decimal32Format.encodeBID(_Decimal32Format.inf(sign)).Uint64())
+}
+
+// Decimal32FromBID returns the decimal32 of the BID encoding b.
+func Decimal32FromBID(b uint32) Decimal32 {
+	return Decimal32(b)
+}
+
+// Decimal32FromDPD returns the decimal32 of the DPD encoding b.
+func Decimal32FromDPD(b uint32) Decimal32 {
+	v := _Decimal32Format.decodeDPD(new(big.Int).SetUint64(uint64(b)))
+	return Decimal32(_Decimal32Format.encodeBID(v).Uint64())
+}
+
+func (x Decimal32) value() *ieeeValue {
+	return _Decimal32Format.decodeBID(new(big.Int).SetUint64(uint64(x)))
+}
+
+// BID returns the BID encoding of x.
+func (x Decimal32) BID() uint32 {
+	return uint32(x)
+}
+
+// DPD returns the DPD encoding of x.
+func (x Decimal32) DPD() uint32 {
+	return uint32(_Decimal32Format.encodeDPD(x.value()).Uint64())
+}
+
+// Decimal returns a new decimal of x, ErrNaN or ErrInfinity is returned for the special values.
+func (x Decimal32) Decimal() (*Decimal, error) {
+	return x.value().toDecimal()
+}
+
+// IsNaN reports whether x is a NaN.
+func (x Decimal32) IsNaN() bool {
+	return x.value().isNaN()
+}
+
+// IsInf reports whether x is an infinity, according to sign (as math.IsInf).
+func (x Decimal32) IsInf(sign int) bool {
+	return x.value().isInf(sign)
+}
+
+// String converts x to a string.
+func (x Decimal32) String() string {
+	return x.value().String()
+}
+
+// Decimal64 represents an IEEE 754-2008 decimal64 value (16 digits) in BID encoding.
+type Decimal64 uint64
+
+// Decimal64 returns the decimal64 value nearest to d and a boolean indicating whether is exact.
+func (d *Decimal) Decimal64() (Decimal64, bool) {
+	v, exact := _Decimal64Format.fromDecimal(d)
+	return Decimal64(_Decimal64Format.encodeBID(v).Uint64()), exact
+}
+
+// Decimal64NaN returns a decimal64 NaN.
+func Decimal64NaN() Decimal64 {
+	return Decimal64(_Decimal64Format.encodeBID(_Decimal64Format.nan()).Uint64())
+}
+
+// Decimal64Inf returns positive infinity if sign >= 0, negative infinity if sign < 0.
+func Decimal64Inf(sign int) Decimal64 {
+	return Decimal64(_Decimal64Format.encodeBID(_Decimal64Format.inf(sign)).Uint64())
+}
+
+// Decimal64FromBID returns the decimal64 of the BID encoding b.
+func Decimal64FromBID(b uint64) Decimal64 {
+	return Decimal64(b)
+}
+
+// Decimal64FromDPD returns the decimal64 of the DPD encoding b.
+func Decimal64FromDPD(b uint64) Decimal64 {
+	v := _Decimal64Format.decodeDPD(new(big.Int).SetUint64(b))
+	return Decimal64(_Decimal64Format.encodeBID(v).Uint64())
+}
+
+func (x Decimal64) value() *ieeeValue {
+	return _Decimal64Format.decodeBID(new(big.Int).SetUint64(uint64(x)))
+}
+
+// BID returns the BID encoding of x.
+func (x Decimal64) BID() uint64 {
+	return uint64(x)
+}
+
+// DPD returns the DPD encoding of x.
+func (x Decimal64) DPD() uint64 {
+	return _Decimal64Format.encodeDPD(x.value()).Uint64()
+}
+
+// Decimal returns a new decimal of x, ErrNaN or ErrInfinity is returned for the special values.
+func (x Decimal64) Decimal() (*Decimal, error) {
+	return x.value().toDecimal()
+}
+
+// IsNaN reports whether x is a NaN.
+func (x Decimal64) IsNaN() bool {
+	return x.value().isNaN()
+}
+
+// IsInf reports whether x is an infinity, according to sign (as math.IsInf).
+func (x Decimal64) IsInf(sign int) bool {
+	return x.value().isInf(sign)
+}
+
+// String converts x to a string.
+func (x Decimal64) String() string {
+	return x.value().String()
+}
+
+// Decimal128 represents an IEEE 754-2008 decimal128 value (34 digits) in BID encoding.
+type Decimal128 struct {
+	hi, lo uint64
+}
+
+func decimal128FromBits(z *big.Int) Decimal128 {
+	return Decimal128{hi: new(big.Int).Rsh(z, 64).Uint64(), lo: bitsOf(z, 0, 64).Uint64()}
+}
+
+func bitsFromUint128(hi, lo uint64) *big.Int {
+	z := new(big.Int).SetUint64(hi)
+	return z.Lsh(z, 64).Or(z, new(big.Int).SetUint64(lo))
+}
+
+// Decimal128 returns the decimal128 value nearest to d and a boolean indicating whether is exact.
+func (d *Decimal) Decimal128() (Decimal128, bool) {
+	v, exact := _Decimal128Format.fromDecimal(d)
+	return decimal128FromBits(_Decimal128Format.encodeBID(v)), exact
+}
+
+// Decimal128NaN returns a decimal128 NaN.
+func Decimal128NaN() Decimal128 {
+	return decimal128FromBits(_Decimal128Format.encodeBID(_Decimal128Format.nan()))
+}
+
+// Decimal128Inf returns positive infinity if sign >= 0, negative infinity if sign < 0.
+func Decimal128Inf(sign int) Decimal128 {
+	return decimal128FromBits(_Decimal128Format.encodeBID(_Decimal128Format.inf(sign)))
+}
+
+// Decimal128FromBID returns the decimal128 of the BID encoding hi:lo.
+func Decimal128FromBID(hi, lo uint64) Decimal128 {
+	return Decimal128{hi: hi, lo: lo}
+}
+
+// Decimal128FromDPD returns the decimal128 of the DPD encoding hi:lo.
+func Decimal128FromDPD(hi, lo uint64) Decimal128 {
+	v := _Decimal128Format.decodeDPD(bitsFromUint128(hi, lo))
+	return decimal128FromBits(_Decimal128Format.encodeBID(v))
+}
+
+func (x Decimal128) value() *ieeeValue {
+	return _Decimal128Format.decodeBID(bitsFromUint128(x.hi, x.lo))
+}
+
+// BID returns the BID encoding of x (the high and low 64 bits).
+func (x Decimal128) BID() (hi, lo uint64) {
+	return x.hi, x.lo
+}
+
+// DPD returns the DPD encoding of x (the high and low 64 bits).
+func (x Decimal128) DPD() (hi, lo uint64) {
+	y := decimal128FromBits(_Decimal128Format.encodeDPD(x.value()))
+	return y.hi, y.lo
+}
+
+// Decimal returns a new decimal of x, ErrNaN or ErrInfinity is returned for the special values.
+func (x Decimal128) Decimal() (*Decimal, error) {
+	return x.value().toDecimal()
+}
+
+// IsNaN reports whether x is a NaN.
+func (x Decimal128) IsNaN() bool {
+	return x.value().isNaN()
+}
+
+// IsInf reports whether x is an infinity, according to sign (as math.IsInf).
+func (x Decimal128) IsInf(sign int) bool {
+	return x.value().isInf(sign)
+}
+
+// String converts x to a string.
+func (x Decimal128) String() string {
+	return x.value().String()
+}
@@ -0,0 +1,104 @@
+package big
+
+import (
+	"testing"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+func TestIEEE754(t *testing.T) {
+	// test encodings of known values
+	x32, exact := MustParseDecimal("1").Decimal32()
+	testing2.AssertEqual(t, exact, true)
+	testing2.AssertEqual(t, x32.BID(), uint32(0x32800001))
+	testing2.AssertEqual(t, x32.DPD(), uint32(0x22500001))
+	x64, _ := MustParseDecimal("1").Decimal64()
+	testing2.AssertEqual(t, x64.BID(), uint64(0x31C0000000000001))
+	testing2.AssertEqual(t, x64.DPD(), uint64(0x2238000000000001))
+	x128, _ := MustParseDecimal("1").Decimal128()
+	hi, lo := x128.BID()
+	testing2.AssertEqual(t, [2]uint64{hi, lo}, [2]uint64{0x3040000000000000, 1})
+	hi, lo = x128.DPD()
+	testing2.AssertEqual(t, [2]uint64{hi, lo}, [2]uint64{0x2208000000000000, 1})
+	x64, exact = MustParseDecimal("9999999999999999e369").Decimal64()
+	testing2.AssertEqual(t, exact, true)
+	testing2.AssertEqual(t, x64.BID(), uint64(0x77FB86F26FC0FFFF))
+	testing2.AssertEqual(t, x64.DPD(), uint64(0x77FCFF3FCFF3FCFF))
+	testing2.AssertEqual(t, Decimal32FromDPD(0xA23003D0).String(), "-7.5")
+	testing2.AssertEqual(t, Decimal64FromDPD(0x77FCFF3FCFF3FCFF).BID(), uint64(0x77FB86F26FC0FFFF))
+
+	// test special values
+	testing2.AssertEqual(t, Decimal64Inf(1).BID(), uint64(0x7800000000000000))
+	testing2.AssertEqual(t, Decimal64Inf(-1).BID(), uint64(0xF800000000000000))
+	testing2.AssertEqual(t, Decimal64NaN().BID(), uint64(0x7C00000000000000))
+	testing2.AssertEqual(t, Decimal64NaN().IsNaN(), true)
+	testing2.AssertEqual(t, Decimal64Inf(-1).IsInf(-1), true)
+	testing2.AssertEqual(t, Decimal64Inf(-1).IsInf(1), false)
+	testing2.AssertEqual(t, Decimal32Inf(1).IsInf(0), true)
+	testing2.AssertEqual(t, Decimal32NaN().IsNaN(), true)
+	testing2.AssertEqual(t, Decimal128NaN().IsNaN(), true)
+	testing2.AssertEqual(t, Decimal128Inf(1).String(), "+Inf")
+	testing2.AssertEqual(t, Decimal64Inf(-1).String(), "-Inf")
+	testing2.AssertEqual(t, Decimal32NaN().String(), "NaN")
+	testing2.AssertEqual(t, Decimal64FromDPD(Decimal64NaN().DPD()).IsNaN(), true)
+	_, err := Decimal64NaN().Decimal()
+	testing2.AssertEqual(t, err, ErrNaN)
+	_, err = Decimal128Inf(1).Decimal()
+	testing2.AssertEqual(t, err, ErrInfinity)
+	testing2.AssertEqual(t, Decimal64FromBID(0x7E00000000000000).IsNaN(), true) // signaling NaN
+
+	// test round trip
+	for _, v := range []string{"0", "-1", "0.1", "123.456", "-9999999", "1e-101", "9999999e90", "1e90"} {
+		x, exact := MustParseDecimal(v).Decimal32()
+		testing2.AssertEqual(t, exact, true)
+		d, err := x.Decimal()
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.Cmp(MustParseDecimal(v)), 0)
+		d, err = Decimal32FromDPD(x.DPD()).Decimal()
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.Cmp(MustParseDecimal(v)), 0)
+	}
+	for _, v := range []string{"0", "-19.99", "1234567890123456", "-1e-398", "1e369", "0.0000000000000001"} {
+		x, exact := MustParseDecimal(v).Decimal64()
+		testing2.AssertEqual(t, exact, true)
+		testing2.AssertEqual(t, x.String(), MustParseDecimal(v).String())
+		testing2.AssertEqual(t, Decimal64FromDPD(x.DPD()), x)
+	}
+	for _, v := range []string{"0", "-1234567890123456789012345678901234", "3.141592653589793238462643383279502", "1e-6176", "-1e6144"} {
+		x, exact := MustParseDecimal(v).Decimal128()
+		testing2.AssertEqual(t, exact, true)
+		testing2.AssertEqual(t, x.String(), MustParseDecimal(v).String())
+		testing2.AssertEqual(t, Decimal128FromDPD(x.DPD()), x)
+	}
+	for n := 0; n < 1000; n++ {
+		testing2.AssertEqual(t, int(_DPDDecode[_DPDEncode[n]]), n)
+	}
+	testing2.AssertEqual(t, _DPDEncode[999], uint16(0x0FF))
+	testing2.AssertEqual(t, _DPDDecode[0x3FF], uint16(999))
+
+	// test rounding and clamping
+	data := map[string][2]interface{}{ // decimal32 string, exact
+		"12345678":     {"12345680", false},
+		"12345685":     {"12345680", false},
+		"12345675":     {"12345680", false},
+		"-1.23456749":  {"-1.234567", false},
+		"9999999.5":    {"10000000", false},
+		"1e96":         {"1e96", true},
+		"1.5e-101":     {"2e-101", false},
+		"2.5e-101":     {"2e-101", false},
+		"1e-200":       {"0", false},
+		"0.0000001e-1": {"1e-8", true},
+	}
+	for k, v := range data {
+		x, exact := MustParseDecimal(k).Decimal32()
+		testing2.AssertEqual(t, x.String(), MustParseDecimal(v[0].(string)).String())
+		testing2.AssertEqual(t, exact, v[1])
+	}
+	x32, exact = MustParseDecimal("1e97").Decimal32()
+	testing2.AssertEqual(t, x32.IsInf(1), true)
+	testing2.AssertEqual(t, exact, false)
+	x32, _ = MustParseDecimal("-99999995e89").Decimal32()
+	testing2.AssertEqual(t, x32.IsInf(-1), true)
+	x64, _ = MustParseDecimal("1e-1000").Decimal64()
+	testing2.AssertEqual(t, x64.String(), "0")
+}
@@ -0,0 +1,83 @@
+package big
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/golang-plus/errors"
+)
+
+// DecodeJSON decodes the JSON document data into an untyped value (as encoding/json does for interface{})
+// but every number is decoded to *Decimal instead of float64, so no digits are lost.
+func DecodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after top-level JSON value")
+	}
+	return ConvertJSONNumbers(v)
+}
+
+// ConvertJSONNumbers walks the untyped value v (built by encoding/json with UseNumber)
+// and converts every json.Number to *Decimal.
+// Maps and slices are modified in place, the converted value is returned.
+func ConvertJSONNumbers(v interface{}) (interface{}, error) {
+	switch x := v.(type) {
+	case json.Number:
+		return ParseDecimal(string(x))
+	case map[string]interface{}:
+		for k, e := range x {
+			y, err := ConvertJSONNumbers(e)
+			if err != nil {
+				return nil, err
+			}
+			x[k] = y
+		}
+	case []interface{}:
+		for i, e := range x {
+			y, err := ConvertJSONNumbers(e)
+			if err != nil {
+				return nil, err
+			}
+			x[i] = y
+		}
+	}
+	return v, nil
+}
+
+// EncodeJSON returns the JSON encoding of the untyped value v,
+// every *Decimal (or Decimal) in v is encoded as a JSON number with all of its digits.
+// v is not changed.
+func EncodeJSON(v interface{}) ([]byte, error) {
+	return json.Marshal(toJSONNumbers(v))
+}
+
+func toJSONNumbers(v interface{}) interface{} {
+	switch x := v.(type) {
+	case *Decimal:
+		if x == nil {
+			return nil
+		}
+		return json.Number(x.String())
+	case Decimal:
+		return json.Number(x.String())
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(x))
+		for k, e := range x {
+			m[k] = toJSONNumbers(e)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(x))
+		for i, e := range x {
+			s[i] = toJSONNumbers(e)
+		}
+		return s
+	}
+	return v
+}
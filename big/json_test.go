@@ -0,0 +1,47 @@
+package big
+
+import (
+	"testing"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+func TestJSON(t *testing.T) {
+	// test DecodeJSON
+	v, err := DecodeJSON([]byte(`{"amount": 12345678901234567890.123456789, "items": [0.1, -2e3, "x", true, null], "nested": {"rate": 1.0000000000000000001}}`))
+	testing2.AssertEqual(t, err, nil)
+	m := v.(map[string]interface{})
+	testing2.AssertEqual(t, m["amount"].(*Decimal).String(), "12345678901234567890.123456789")
+	items := m["items"].([]interface{})
+	testing2.AssertEqual(t, items[0].(*Decimal).String(), "0.1")
+	testing2.AssertEqual(t, items[1].(*Decimal).String(), "-2000")
+	testing2.AssertEqual(t, items[2], "x")
+	testing2.AssertEqual(t, items[3], true)
+	testing2.AssertEqual(t, items[4], nil)
+	testing2.AssertEqual(t, m["nested"].(map[string]interface{})["rate"].(*Decimal).String(), "1.0000000000000000001")
+
+	v, err = DecodeJSON([]byte(`3.14159265358979323846264338327950288`))
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, v.(*Decimal).String(), "3.14159265358979323846264338327950288")
+
+	for _, s := range []string{``, `{`, `1 2`, `[1,]`, `{"a":1} }`, `1 ]`} {
+		_, err := DecodeJSON([]byte(s))
+		testing2.AssertNotEqual(t, err, nil)
+	}
+
+	// test EncodeJSON
+	data, err := EncodeJSON(map[string]interface{}{
+		"amount": MustParseDecimal("12345678901234567890.123456789"),
+		"items":  []interface{}{MustParseDecimal("-0.001"), *MustParseDecimal("1e3"), (*Decimal)(nil), "x"},
+	})
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, string(data), `{"amount":12345678901234567890.123456789,"items":[-0.001,1000,null,"x"]}`)
+
+	// test round trip
+	doc := `{"a":[1.10,0.000000000000000000000001],"b":-98765432109876543210.5}`
+	v, err = DecodeJSON([]byte(doc))
+	testing2.AssertEqual(t, err, nil)
+	data, err = EncodeJSON(v)
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, string(data), `{"a":[1.1,0.000000000000000000000001],"b":-98765432109876543210.5}`)
+}
@@ -0,0 +1,195 @@
+package big
+
+import (
+	"math/big"
+
+	"github.com/golang-plus/errors"
+)
+
+// Limits represents the resource limits of decimals from untrusted input, a zero field means unlimited.
+// The limits are enforced by parsing (see ParseOptions), decoding and the arithmetic methods of Limits.
+type Limits struct {
+	MaxDigits             int // max digits of the coefficient
+	MaxExponent           int // max magnitude of the exponent
+	MaxIntermediateDigits int // max digits of the coefficients computed during arithmetic (e.g. aligned operands)
+}
+
+var (
+	// DecimalLimits is the limits enforced by ParseDecimal, SetString, SetBytes, UnmarshalText, the decoders of binary formats
+	// and the arithmetic methods of Decimal (which panic if the limits are exceeded, see Limits.Add for the error).
+	// It should be set before any decimal is used, set it to Limits{} (unlimited) to opt out explicitly.
+	DecimalLimits = Limits{MaxDigits: 100000, MaxExponent: 100000, MaxIntermediateDigits: 1000000}
+)
+
+// numDigits returns the number of decimal digits of |x| (1 for zero).
+func numDigits(x *big.Int) int {
+	if x.IsInt64() {
+		return int64Digits(x.Int64())
+	}
+	n := int(float64(x.BitLen())*0.30102999566398120) + 1 // log10(2)
+	if x.CmpAbs(pow10(n-1)) < 0 {
+		n--
+	}
+	return n
+}
+
+// int64Digits returns the number of decimal digits of |v| (1 for zero).
+func int64Digits(v int64) int {
+	n := 1
+	for v >= 10 || v <= -10 {
+		v /= 10
+		n++
+	}
+	return n
+}
+
+// coefficientDigits returns the number of decimal digits of the coefficient of d.
+func coefficientDigits(d *Decimal) int {
+	if d.isInline() {
+		return int64Digits(d.small)
+	}
+	return numDigits(d.integer)
+}
+
+// Check returns an error if d exceeds the limits of digits or exponent.
+func (l Limits) Check(d *Decimal) error {
+	if err := l.checkExponent(int64(d.exponent)); err != nil {
+		return err
+	}
+	if l.MaxDigits > 0 && (l.MaxDigits < 19 || !d.isInline()) { // an inline coefficient has at most 19 digits
+		if n := coefficientDigits(d); n > l.MaxDigits {
+			return errors.Newf("%d digits of decimal exceeds the limit %d", n, l.MaxDigits)
+		}
+	}
+	return nil
+}
+
+// check returns an error if the decimal of coefficient c and the exponent exceeds the limits.
+func (l Limits) check(c *big.Int, exponent int) error {
+	return l.Check(&Decimal{integer: c, exponent: exponent})
+}
+
+// checkExponent returns an error if the exponent exceeds the limit of exponent.
+func (l Limits) checkExponent(exponent int64) error {
+	if l.MaxExponent > 0 && (exponent > int64(l.MaxExponent) || exponent < -int64(l.MaxExponent)) {
+		return errors.Newf("exponent %d of decimal exceeds the limit %d", exponent, l.MaxExponent)
+	}
+	return nil
+}
+
+// checkIntermediate returns an error if n digits exceeds the limit of intermediate digits.
+func (l Limits) checkIntermediate(n int64) error {
+	if l.MaxIntermediateDigits > 0 && n > int64(l.MaxIntermediateDigits) {
+		return errors.Newf("%d intermediate digits exceeds the limit %d", n, l.MaxIntermediateDigits)
+	}
+	return nil
+}
+
+// checkOperands checks x and y and the size of aligning them, and returns the digits of the aligned coefficients.
+func (l Limits) checkOperands(x, y *Decimal) (int64, error) {
+	if err := l.Check(x); err != nil {
+		return 0, err
+	}
+	if err := l.Check(y); err != nil {
+		return 0, err
+	}
+	n, m := int64(coefficientDigits(x)), int64(coefficientDigits(y))
+	switch diff := int64(x.exponent) - int64(y.exponent); {
+	case diff > 0:
+		n += diff
+	case diff < 0:
+		m -= diff
+	}
+	if m > n {
+		n = m
+	}
+	return n, l.checkIntermediate(n)
+}
+
+// apply sets x to op(x, y) if the result is within the limits, the exponent of the result must be checked by the caller.
+// n is an upper bound of the digits of the result, the result is computed in x directly if it cannot exceed the limit of digits.
+func (l Limits) apply(x, y *Decimal, n int64, op func(x, y *Decimal) *Decimal) error {
+	if l.MaxDigits <= 0 || n <= int64(l.MaxDigits) {
+		op(x, y)
+		return nil
+	}
+	var z Decimal
+	if err := l.Check(op(z.Copy(x), y)); err != nil {
+		return err
+	}
+	x.Copy(&z)
+	return nil
+}
+
+// Add sets x to the sum x+y within the limits, x is unchanged if an error is returned.
+func (l Limits) Add(x, y *Decimal) error {
+	n, err := l.checkOperands(x, y)
+	if err != nil {
+		return err
+	}
+	return l.apply(x, y, n+1, (*Decimal).add) // the exponent is the less one of x and y
+}
+
+// Sub sets x to the difference x-y within the limits, x is unchanged if an error is returned.
+func (l Limits) Sub(x, y *Decimal) error {
+	n, err := l.checkOperands(x, y)
+	if err != nil {
+		return err
+	}
+	return l.apply(x, y, n+1, (*Decimal).sub)
+}
+
+// Mul sets x to the product x*y within the limits, x is unchanged if an error is returned.
+func (l Limits) Mul(x, y *Decimal) error {
+	if err := l.Check(x); err != nil {
+		return err
+	}
+	if err := l.Check(y); err != nil {
+		return err
+	}
+	n := int64(coefficientDigits(x)) + int64(coefficientDigits(y))
+	if err := l.checkIntermediate(n); err != nil {
+		return err
+	}
+	if !x.IsZero() && !y.IsZero() { // the exponent of zero is 0
+		if err := l.checkExponent(int64(x.exponent) + int64(y.exponent)); err != nil {
+			return err
+		}
+	}
+	return l.apply(x, y, n, (*Decimal).mul)
+}
+
+// Quo sets x to the quotient x/y (see Decimal.Quo) within the limits, x is unchanged if an error is returned.
+func (l Limits) Quo(x, y *Decimal) error {
+	return l.QuoWithMode(x, y, ToZero)
+}
+
+// QuoWithMode sets x to the quotient x/y (see Decimal.QuoWithMode) within the limits, x is unchanged if an error is returned.
+func (l Limits) QuoWithMode(x, y *Decimal, mode RoundingMode) error {
+	if err := l.Check(x); err != nil {
+		return err
+	}
+	if err := l.Check(y); err != nil {
+		return err
+	}
+	r, xbuf, ybuf := getInt(), getInt(), getInt()
+	defer putInt(r)
+	defer putInt(xbuf)
+	defer putInt(ybuf)
+	xc, yc := x.coefficient(xbuf), y.coefficient(ybuf)
+	if yc.Sign() != 0 && r.Rem(xc, yc).Sign() != 0 { // digits are generated up to MaxDecimalDigits decimals
+		n := int64(numDigits(xc))
+		if k := int64(MaxDecimalDigits) + int64(x.exponent) - int64(y.exponent); k > 0 { // x is scaled by 10**k
+			n += k
+		}
+		if err := l.checkIntermediate(n); err != nil {
+			return err
+		}
+	}
+	var z Decimal // the exponent and the digits of the quotient are known after the division
+	if err := l.Check(z.Copy(x).quo(y, mode)); err != nil {
+		return err
+	}
+	x.Copy(&z)
+	return nil
+}
@@ -0,0 +1,146 @@
+package big
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+func TestLimits(t *testing.T) {
+	// test numDigits
+	data := map[string]int{
+		"0": 1, "9": 1, "-10": 2, "9223372036854775807": 19, "-9223372036854775808": 19,
+		"9999999999999999999": 19, "10000000000000000000": 20, "-123456789012345678901234567890": 30,
+	}
+	for k, v := range data {
+		x, _ := new(big.Int).SetString(k, 10)
+		testing2.AssertEqual(t, numDigits(x), v)
+	}
+
+	defaults := DecimalLimits
+	defer func() { DecimalLimits = defaults }()
+	DecimalLimits = Limits{} // opt out, the tests below use their own limits
+
+	limits := Limits{MaxDigits: 10, MaxExponent: 20, MaxIntermediateDigits: 30}
+	defer func(n uint) { MaxDecimalDigits = n }(MaxDecimalDigits)
+	MaxDecimalDigits = 50
+
+	// test Check
+	for _, v := range []string{"0", "1234567890", "-1e20", "1e-20", "0.1234567891"} {
+		testing2.AssertEqual(t, limits.Check(MustParseDecimal(v)), nil)
+	}
+	for _, v := range []string{"12345678901", "1e21", "-1e-21", "0.12345678901"} {
+		testing2.AssertNotEqual(t, limits.Check(MustParseDecimal(v)), nil)
+	}
+
+	// test Add Sub Mul Quo
+	x := MustParseDecimal("1.5")
+	testing2.AssertEqual(t, limits.Add(x, MustParseDecimal("2.25")), nil)
+	testing2.AssertEqual(t, x.String(), "3.75")
+	testing2.AssertEqual(t, limits.Sub(x, MustParseDecimal("0.75")), nil)
+	testing2.AssertEqual(t, x.String(), "3")
+	testing2.AssertEqual(t, limits.Mul(x, MustParseDecimal("-1.5")), nil)
+	testing2.AssertEqual(t, x.String(), "-4.5")
+	testing2.AssertEqual(t, limits.Quo(x, MustParseDecimal("2")), nil)
+	testing2.AssertEqual(t, x.String(), "-2.25")
+
+	// test errors (x unchanged)
+	testing2.AssertNotEqual(t, limits.Add(x, MustParseDecimal("1e20")), nil)                         // result exceeds MaxDigits
+	testing2.AssertNotEqual(t, limits.Add(x, MustParseDecimal("1e21")), nil)                         // operand exceeds MaxExponent
+	testing2.AssertNotEqual(t, limits.Sub(MustParseDecimal("1e20"), MustParseDecimal("1e-20")), nil) // 41 intermediate digits
+	testing2.AssertNotEqual(t, limits.Mul(x, MustParseDecimal("1e-19")), nil)                        // exponent exceeds MaxExponent
+	testing2.AssertNotEqual(t, limits.Mul(MustParseDecimal("99999"), MustParseDecimal("99999.99999")), nil)
+	testing2.AssertNotEqual(t, limits.Quo(x, MustParseDecimal("7")), nil) // MaxDecimalDigits decimal digits
+	testing2.AssertEqual(t, x.String(), "-2.25")
+	testing2.AssertEqual(t, Limits{}.Quo(x, MustParseDecimal("1e-999999")), nil)
+	testing2.AssertEqual(t, x.Cmp(MustParseDecimal("-2.25e999999")), 0)
+	tiny := MustParseDecimal("1e-20000000") // truncated at the exponent of x/y, not scaled
+	testing2.AssertEqual(t, Limits{MaxDigits: 100, MaxExponent: 20000000, MaxIntermediateDigits: 2000}.Quo(tiny, NewDecimal(3)), nil)
+	testing2.AssertEqual(t, tiny.IsZero(), true)
+
+	// test parsing
+	DecimalLimits = limits
+	for _, v := range []string{"1234567890", "0.0001234567890", "1e20", "-12.5e-19"} {
+		_, err := ParseDecimal(v)
+		testing2.AssertEqual(t, err, nil)
+	}
+	data2 := map[string][2]int{ // offset, reason
+		"12345678901":             {10, int(TooManyDigits)},
+		"-0.001234567890123":      {15, int(TooManyDigits)},
+		"1e999999999":             {1, int(ExponentOutOfRange)},
+		"1.5e-20":                 {3, int(ExponentOutOfRange)},
+		"0.000000000000000000001": {23, int(ExponentOutOfRange)},
+	}
+	for k, v := range data2 {
+		_, err := ParseDecimal(k)
+		e, ok := err.(*ParseError)
+		testing2.AssertEqual(t, ok, true)
+		testing2.AssertEqual(t, e.Offset, v[0])
+		testing2.AssertEqual(t, int(e.Reason), v[1])
+	}
+	d := MustParseDecimal("1.5")
+	_, ok := d.SetString("1e999999999")
+	testing2.AssertEqual(t, ok, false)
+	testing2.AssertNotEqual(t, d.UnmarshalText([]byte("1e999999999")), nil)
+	testing2.AssertEqual(t, d.String(), "1.5")
+	_, err := ParseDecimalWith("1e999999999", StrictParseOptions)
+	testing2.AssertEqual(t, err, nil)
+
+	// test arithmetic methods of Decimal (panic with the error of DecimalLimits)
+	testing2.AssertEqual(t, MustParseDecimal("1e10").Mul(MustParseDecimal("1e10")).String(), "100000000000000000000")
+	for _, f := range []func(){
+		func() { MustParseDecimal("1e20").Mul(MustParseDecimal("1e1")) },
+		func() { MustParseDecimal("1e20").Add(MustParseDecimal("1e-20")) },
+		func() { MustParseDecimal("9999999999").Sub(MustParseDecimal("-1")) },
+		func() { MustParseDecimal("1e-20").Quo(MustParseDecimal("1e5")) },
+		func() { MustParseDecimal("1").QuoWithMode(MustParseDecimal("3"), ToNearestEven) },
+	} {
+		err := func() (err interface{}) {
+			defer func() { err = recover() }()
+			f()
+			return nil
+		}()
+		testing2.AssertNotEqual(t, err, nil)
+	}
+
+	// test String (the exponential form beyond the limit of exponent)
+	DecimalLimits = Limits{MaxExponent: 20}
+	huge := &Decimal{small: -15, inline: true, exponent: 999999999}
+	testing2.AssertEqual(t, huge.String(), "-1.5e+1000000000")
+	testing2.AssertEqual(t, (&Decimal{small: 1, inline: true, exponent: -21}).String(), "1e-21")
+	testing2.AssertEqual(t, MustParseDecimal("1e20").String(), "100000000000000000000")
+
+	// test decoders
+	var z Decimal
+	data3, _ := huge.MarshalBinary()
+	testing2.AssertNotEqual(t, z.UnmarshalBinary(data3), nil)
+	_, err = z.DecodeCompact(huge.AppendCompact(nil))
+	testing2.AssertNotEqual(t, err, nil)
+	data3, _ = huge.MarshalCBOR()
+	testing2.AssertNotEqual(t, z.UnmarshalCBOR(data3), nil)
+	data3, _ = huge.MarshalMsgpack()
+	testing2.AssertNotEqual(t, z.UnmarshalMsgpack(data3), nil)
+	_, _, err = DecodeSortableKey(huge.AppendSortableKey(nil))
+	testing2.AssertNotEqual(t, err, nil)
+	data3, _ = EncodePostgresNumeric(&Decimal{small: 1, inline: true, exponent: 24})
+	_, err = DecodePostgresNumeric(data3)
+	testing2.AssertNotEqual(t, err, nil)
+	testing2.AssertEqual(t, z.IsZero(), true)
+	data3 = MustParseDecimal(strings.Repeat("9", 26)).AppendCompact(nil)
+	DecimalLimits = Limits{MaxDigits: 25}
+	_, err = z.DecodeCompact(data3)
+	testing2.AssertNotEqual(t, err, nil)
+	DecimalLimits = Limits{}
+	data3, _ = huge.MarshalBinary()
+	testing2.AssertEqual(t, z.UnmarshalBinary(data3), nil)
+	testing2.AssertEqual(t, z.Cmp(huge), 0)
+
+	// test the default limits
+	testing2.AssertEqual(t, defaults.MaxDigits > 0 && defaults.MaxExponent > 0 && defaults.MaxIntermediateDigits > 0, true)
+	testing2.AssertEqual(t, defaults.Check(MustParseDecimal("1e100000")), nil)
+	testing2.AssertNotEqual(t, defaults.Check(huge), nil)
+	_, err = ParseDecimalWith("1e999999999", ParseOptions{Limits: defaults})
+	testing2.AssertNotEqual(t, err, nil)
+}
@@ -0,0 +1,145 @@
+package big
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/golang-plus/errors"
+)
+
+const (
+	_MySQLDecimalMaxPrecision = 65
+	_MySQLDecimalMaxScale     = 30
+	_MySQLDigitsPerWord       = 9
+	_MySQLBytesPerWord        = 4
+)
+
+// number of bytes used by the leftover digits (less than a word) of MySQL DECIMAL.
+var _MySQLDigitsToBytes = [_MySQLDigitsPerWord + 1]int{0, 1, 1, 2, 2, 3, 3, 4, 4, 4}
+
+func checkMySQLDecimalType(precision, scale int) error {
+	if precision < 1 || precision > _MySQLDecimalMaxPrecision || scale < 0 || scale > _MySQLDecimalMaxScale || scale > precision {
+		return errors.Newf("MySQL DECIMAL(%d,%d) is invalid", precision, scale)
+	}
+	return nil
+}
+
+// MySQLDecimalSize returns the number of bytes used by the binary storage of MySQL DECIMAL(precision,scale).
+// An error is returned if the type is invalid (0 <= scale <= 30, scale <= precision and 1 <= precision <= 65).
+func MySQLDecimalSize(precision, scale int) (int, error) {
+	if err := checkMySQLDecimalType(precision, scale); err != nil {
+		return 0, err
+	}
+	return mysqlDecimalSize(precision, scale), nil
+}
+
+// mysqlDecimalSize returns the number of bytes of the valid type DECIMAL(precision,scale).
+func mysqlDecimalSize(precision, scale int) int {
+	intg := precision - scale
+	return intg/_MySQLDigitsPerWord*_MySQLBytesPerWord + _MySQLDigitsToBytes[intg%_MySQLDigitsPerWord] +
+		scale/_MySQLDigitsPerWord*_MySQLBytesPerWord + _MySQLDigitsToBytes[scale%_MySQLDigitsPerWord]
+}
+
+// AppendMySQLDecimal appends the MySQL binary storage of d as DECIMAL(precision,scale) to dst and returns the extended buffer.
+// Digits are packed 9 per 4 bytes (leftover digits use fewer bytes), negative values are stored inverted and the sign bit of the first byte is flipped.
+// An error is returned if d does not fit the declared type (it is not rounded, please round d to scale first).
+func AppendMySQLDecimal(dst []byte, d *Decimal, precision, scale int) ([]byte, error) {
+	if err := checkMySQLDecimalType(precision, scale); err != nil {
+		return nil, err
+	}
+	scaled, exact, fits := d.scaledIntegerWithin(precision, scale)
+	if !exact {
+		return nil, errors.Newf("decimal has more than %d decimal digits", scale)
+	}
+	if !fits {
+		return nil, errors.Newf("decimal overflows MySQL DECIMAL(%d,%d)", precision, scale)
+	}
+	str := scaled.Abs(scaled).String()
+	str = strings.Repeat("0", precision-len(str)) + str
+
+	start := len(dst)
+	intg := precision - scale
+	dst = appendMySQLDigits(dst, str[:intg%_MySQLDigitsPerWord])
+	for i := intg % _MySQLDigitsPerWord; i < precision-scale%_MySQLDigitsPerWord; i += _MySQLDigitsPerWord {
+		dst = appendMySQLDigits(dst, str[i:i+_MySQLDigitsPerWord])
+	}
+	dst = appendMySQLDigits(dst, str[precision-scale%_MySQLDigitsPerWord:])
+	if d.Sign() < 0 {
+		for i := start; i < len(dst); i++ {
+			dst[i] = ^dst[i]
+		}
+	}
+	dst[start] ^= 0x80
+	return dst, nil
+}
+
+// appendMySQLDigits appends the big-endian value of digits (at most 9 digits) to dst.
+func appendMySQLDigits(dst []byte, digits string) []byte {
+	if len(digits) == 0 {
+		return dst
+	}
+	v, _ := strconv.ParseUint(digits, 10, 32)
+	for i := _MySQLDigitsToBytes[len(digits)] - 1; i >= 0; i-- {
+		dst = append(dst, byte(v>>(uint(i)*8)))
+	}
+	return dst
+}
+
+// EncodeMySQLDecimal returns the MySQL binary storage of d as DECIMAL(precision,scale).
+func EncodeMySQLDecimal(d *Decimal, precision, scale int) ([]byte, error) {
+	return AppendMySQLDecimal(nil, d, precision, scale)
+}
+
+// DecodeMySQLDecimal returns a new decimal by decoding the MySQL binary storage of DECIMAL(precision,scale).
+func DecodeMySQLDecimal(data []byte, precision, scale int) (*Decimal, error) {
+	if err := checkMySQLDecimalType(precision, scale); err != nil {
+		return nil, err
+	}
+	if size := mysqlDecimalSize(precision, scale); len(data) != size {
+		return nil, errors.Newf("MySQL DECIMAL(%d,%d) data must be %d bytes", precision, scale, size)
+	}
+	buf := make([]byte, len(data))
+	copy(buf, data)
+	negative := buf[0]&0x80 == 0
+	buf[0] ^= 0x80
+	if negative {
+		for i := range buf {
+			buf[i] = ^buf[i]
+		}
+	}
+
+	var str strings.Builder
+	str.Grow(precision + 1)
+	if negative {
+		str.WriteByte('-')
+	}
+	intg := precision - scale
+	groups := []int{intg % _MySQLDigitsPerWord}
+	for i := 0; i < intg/_MySQLDigitsPerWord+scale/_MySQLDigitsPerWord; i++ {
+		groups = append(groups, _MySQLDigitsPerWord)
+	}
+	groups = append(groups, scale%_MySQLDigitsPerWord)
+	for _, digits := range groups {
+		if digits == 0 {
+			continue
+		}
+		var v uint64
+		for n := _MySQLDigitsToBytes[digits]; n > 0; n-- {
+			v = v<<8 | uint64(buf[0])
+			buf = buf[1:]
+		}
+		s := strconv.FormatUint(v, 10)
+		if len(s) > digits {
+			return nil, errors.Newf("MySQL DECIMAL(%d,%d) data is invalid", precision, scale)
+		}
+		str.WriteString(strings.Repeat("0", digits-len(s)))
+		str.WriteString(s)
+	}
+
+	d := new(Decimal)
+	d.ensureInitialized()
+	d.integer.SetString(str.String(), 10)
+	d.exponent = scale * -1
+	d.trimFraction()
+	return d, nil
+}
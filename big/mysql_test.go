@@ -0,0 +1,86 @@
+package big
+
+import (
+	"testing"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+func TestMySQLDecimal(t *testing.T) {
+	// test MySQLDecimalSize
+	for k, v := range map[[2]int]int{{14, 4}: 7, {10, 2}: 5, {65, 30}: 30, {18, 9}: 8, {1, 0}: 1} {
+		size, err := MySQLDecimalSize(k[0], k[1])
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, size, v)
+	}
+	for _, v := range [][2]int{{5, 6}, {-1, 0}, {10, -2}, {66, 2}, {0, 0}, {40, 31}} { // invalid (no panic)
+		_, err := MySQLDecimalSize(v[0], v[1])
+		testing2.AssertNotEqual(t, err, nil)
+	}
+
+	// test EncodeMySQLDecimal DecodeMySQLDecimal
+	data := map[string][]byte{
+		"1234567890.1234":  {0x81, 0x0D, 0xFB, 0x38, 0xD2, 0x04, 0xD2},
+		"-1234567890.1234": {0x7E, 0xF2, 0x04, 0xC7, 0x2D, 0xFB, 0x2D},
+		"0":                {0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		"1.5":              {0x80, 0x00, 0x00, 0x00, 0x01, 0x13, 0x88},
+	}
+	for k, v := range data {
+		b, err := EncodeMySQLDecimal(MustParseDecimal(k), 14, 4)
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, b, v)
+		d, err := DecodeMySQLDecimal(v, 14, 4)
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.String(), k)
+	}
+
+	// test round trip
+	types := [][2]int{{1, 0}, {5, 5}, {10, 2}, {18, 9}, {19, 10}, {30, 0}, {65, 30}}
+	values := []string{"0", "1", "-1", "0.1", "-0.00001", "9", "-9", "123.45", "99999999.99"}
+	for _, typ := range types {
+		for _, v := range values {
+			b, err := EncodeMySQLDecimal(MustParseDecimal(v), typ[0], typ[1])
+			if err != nil { // does not fit
+				continue
+			}
+			size, _ := MySQLDecimalSize(typ[0], typ[1])
+			testing2.AssertEqual(t, len(b), size)
+			d, err := DecodeMySQLDecimal(b, typ[0], typ[1])
+			testing2.AssertEqual(t, err, nil)
+			testing2.AssertEqual(t, d.String(), v)
+		}
+	}
+	max := "99999999999999999999999999999999999.999999999999999999999999999999"
+	b, err := EncodeMySQLDecimal(MustParseDecimal("-"+max), 65, 30)
+	testing2.AssertEqual(t, err, nil)
+	d, err := DecodeMySQLDecimal(b, 65, 30)
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, d.String(), "-"+max)
+
+	// test overflow
+	for k, v := range map[string][2]int{
+		"1000":   {5, 2},
+		"-1000":  {5, 2},
+		"0.001":  {5, 2},
+		"1e10":   {10, 0},
+		"0.5":    {1, 0},
+		"123.45": {4, 1},
+	} {
+		_, err := EncodeMySQLDecimal(MustParseDecimal(k), v[0], v[1])
+		testing2.AssertNotEqual(t, err, nil)
+	}
+
+	// test invalid type and data
+	for _, typ := range [][2]int{{0, 0}, {66, 0}, {40, 31}, {5, 6}, {5, -1}} {
+		_, err := EncodeMySQLDecimal(MustParseDecimal("0"), typ[0], typ[1])
+		testing2.AssertNotEqual(t, err, nil)
+		_, err = DecodeMySQLDecimal(nil, typ[0], typ[1])
+		testing2.AssertNotEqual(t, err, nil)
+	}
+	_, err = DecodeMySQLDecimal([]byte{0x80, 0x00}, 14, 4)
+	testing2.AssertNotEqual(t, err, nil)
+	_, err = DecodeMySQLDecimal([]byte{0xE3}, 2, 0) // 99
+	testing2.AssertEqual(t, err, nil)
+	_, err = DecodeMySQLDecimal([]byte{0xE4}, 2, 0) // 100 is not a 2 digit group
+	testing2.AssertNotEqual(t, err, nil)
+}
@@ -0,0 +1,320 @@
+package big
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// The grammar accepted by SetString, SetBytes and ParseDecimal (ASCII only, no whitespace):
+//
+//	decimal  = [ sign ] digits [ "." digits ] [ exponent ]
+//	exponent = ( "e" | "E" ) [ sign ] digits
+//	sign     = "+" | "-"
+//	digits   = digit { digit }
+//	digit    = "0" ... "9"
+//
+// The value is coefficient × 10^exponent, where the coefficient is the integral digits followed by the fractional digits
+// without trailing zeros. The string is rejected if the exponent of the value overflows int.
+//
+// ParseDecimalWith extends the grammar by ParseOptions:
+//
+//	Underscores:   digits = digit { [ "_" ] digit }, an underscore is allowed only between two digits (e.g. "1_000.000_1", "1e1_0")
+//	BareDot:       a bare leading or trailing decimal point is allowed (e.g. ".5", "5.", "+.5e3", but not ".")
+//	Whitespace:    leading and trailing whitespace (" ", "\t", "\n", "\v", "\f", "\r") is ignored
+//	SpecialValues: [ sign ] ( "inf" | "infinity" | "nan" ) in any case is accepted and reported as ErrInfinity or ErrNaN
+//	LeadingSpaces: leading spaces (" ") are ignored
+//	DecimalComma:  the decimal point may be "," (e.g. "1,5")
+//	NoExponent:    the exponent is not allowed (e.g. "1e5" is rejected)
+//
+// The Limits of ParseOptions are enforced on the value: the digits of the coefficient (leading zeros excluded) and the magnitude of the exponent.
+
+// ParseOptions represents the extensions of the grammar of ParseDecimalWith.
+type ParseOptions struct {
+	Underscores   bool // allow underscores between digits
+	BareDot       bool // allow a bare leading or trailing decimal point
+	Whitespace    bool // allow leading and trailing whitespace
+	SpecialValues bool // allow infinity and NaN (reported as ErrInfinity and ErrNaN)
+	LeadingSpaces bool // allow leading spaces
+	DecimalComma  bool // allow "," as the decimal point
+	NoExponent    bool // reject the exponent
+	Limits        Limits
+}
+
+var (
+	// StrictParseOptions is the grammar of ParseDecimal (without limits).
+	StrictParseOptions = ParseOptions{}
+
+	// LenientParseOptions allows all the extensions of the grammar (without limits).
+	LenientParseOptions = ParseOptions{
+		Underscores:   true,
+		BareDot:       true,
+		Whitespace:    true,
+		SpecialValues: true,
+	}
+)
+
+const (
+	_ChunkDigits = 18 // max decimal digits always fitting in uint64 without overflow check
+)
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\v' || c == '\f' || c == '\r'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// equalFold reports whether s equals the lower case ASCII word under case folding.
+func equalFold[T string | []byte](s T, word string) bool {
+	if len(s) != len(word) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c != word[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// scanDigits returns the end of the digits beginning at s[i] and the count of digits in it.
+func scanDigits[T string | []byte](s T, i int, underscores bool) (int, int) {
+	count := 0
+	for i < len(s) {
+		if isDigit(s[i]) {
+			count++
+		} else if !underscores || s[i] != '_' || count == 0 || i+1 == len(s) || !isDigit(s[i+1]) {
+			break
+		}
+		i++
+	}
+	return i, count
+}
+
+// ParseErrorReason represents the reason of a ParseError.
+type ParseErrorReason byte
+
+const (
+	EmptyInput         ParseErrorReason = iota + 1 // the input is empty (or only whitespace)
+	InvalidCharacter                               // a character is invalid at the offset, or the input ends unexpectedly
+	ExponentOutOfRange                             // the exponent (beginning at the offset) overflows int or exceeds the limit
+	TooManyDigits                                  // the digit at the offset exceeds the limit of digits
+)
+
+// String returns the description of r.
+func (r ParseErrorReason) String() string {
+	switch r {
+	case EmptyInput:
+		return "empty input"
+	case InvalidCharacter:
+		return "invalid character"
+	case ExponentOutOfRange:
+		return "exponent out of range"
+	case TooManyDigits:
+		return "too many digits"
+	}
+	return "unknown reason"
+}
+
+// ParseError represents an error of parsing decimal string.
+type ParseError struct {
+	Input  string           // the input being parsed
+	Offset int              // the byte offset in Input where the error is found
+	Reason ParseErrorReason // the reason of the error
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	switch {
+	case e.Reason == EmptyInput:
+		return fmt.Sprintf("decimal string %q is invalid: %s", e.Input, e.Reason)
+	case e.Reason == InvalidCharacter && e.Offset >= len(e.Input):
+		return fmt.Sprintf("decimal string %q is invalid: unexpected end of input", e.Input)
+	case e.Reason == InvalidCharacter:
+		return fmt.Sprintf("decimal string %q is invalid: %s %q at offset %d", e.Input, e.Reason, e.Input[e.Offset], e.Offset)
+	}
+	return fmt.Sprintf("decimal string %q is invalid: %s at offset %d", e.Input, e.Reason, e.Offset)
+}
+
+// setDecimal sets z to the value of str by the grammar extended by opts.
+// z is not changed if str is invalid, and no allocation is made if the coefficient has at most 18 digits.
+// The returned error is a *ParseError, ErrInfinity or ErrNaN.
+func setDecimal[T string | []byte](z *Decimal, str T, opts ParseOptions) error {
+	s, base := str, 0 // base is the offset of s in str
+	if opts.Whitespace {
+		n := len(s)
+		for base < n && isSpace(s[base]) {
+			base++
+		}
+		for n > base && isSpace(s[n-1]) {
+			n--
+		}
+		s = s[base:n]
+	}
+	if opts.LeadingSpaces {
+		skip := 0
+		for skip < len(s) && s[skip] == ' ' {
+			skip++
+		}
+		s, base = s[skip:], base+skip
+	}
+	fail := func(offset int, reason ParseErrorReason) error {
+		return &ParseError{Input: string(str), Offset: base + offset, Reason: reason}
+	}
+	if len(s) == 0 {
+		return fail(0, EmptyInput)
+	}
+	i, n := 0, len(s)
+	negative := false
+	if s[i] == '+' || s[i] == '-' {
+		negative = s[i] == '-'
+		i++
+	}
+	if opts.SpecialValues {
+		if equalFold(s[i:], "inf") || equalFold(s[i:], "infinity") {
+			return ErrInfinity
+		}
+		if equalFold(s[i:], "nan") {
+			return ErrNaN
+		}
+	}
+
+	start := i
+	i, count := scanDigits(s, i, opts.Underscores)
+	end := i // end of the coefficient (trailing zeros of the fraction excluded)
+	decimals := 0
+	if i < n && (s[i] == '.' || opts.DecimalComma && s[i] == ',') && (count > 0 || opts.BareDot) {
+		i++
+		i, decimals = scanDigits(s, i, opts.Underscores)
+		if decimals == 0 && (count == 0 || !opts.BareDot) {
+			return fail(i, InvalidCharacter)
+		}
+		end = i
+		for decimals > 0 && (s[end-1] == '0' || s[end-1] == '_') {
+			if s[end-1] == '0' {
+				decimals--
+			}
+			end--
+		}
+	} else if count == 0 {
+		return fail(i, InvalidCharacter)
+	}
+	var exponent int64
+	mark := n // beginning of the exponent
+	if i < n && (s[i] == 'e' || s[i] == 'E') && !opts.NoExponent {
+		mark = i
+		i++
+		negative := false
+		if i < n && (s[i] == '+' || s[i] == '-') {
+			negative = s[i] == '-'
+			i++
+		}
+		digits := i
+		i, _ = scanDigits(s, i, opts.Underscores)
+		if i == digits {
+			return fail(i, InvalidCharacter)
+		}
+		for j := digits; j < i; j++ {
+			if !isDigit(s[j]) {
+				continue
+			}
+			digit := int64(s[j] - '0')
+			if exponent > (math.MaxInt64-digit)/10 {
+				return fail(mark, ExponentOutOfRange)
+			}
+			exponent = exponent*10 + digit
+		}
+		if negative {
+			exponent = -exponent
+		}
+	}
+	if i != n {
+		return fail(i, InvalidCharacter)
+	}
+	if exponent < math.MinInt64+int64(decimals) || int64(int(exponent-int64(decimals))) != exponent-int64(decimals) {
+		return fail(mark, ExponentOutOfRange)
+	}
+	if max := int64(opts.Limits.MaxExponent); max > 0 && (exponent-int64(decimals) > max || exponent-int64(decimals) < -max) {
+		return fail(mark, ExponentOutOfRange)
+	}
+	if opts.Limits.MaxDigits > 0 {
+		count := 0
+		for i := start; i < end; i++ {
+			if isDigit(s[i]) && (count > 0 || s[i] != '0') {
+				if count++; count > opts.Limits.MaxDigits {
+					return fail(i, TooManyDigits)
+				}
+			}
+		}
+	}
+
+	var small uint64 // the coefficient while it fits in uint64
+	var chunk uint64
+	size := 0 // digits in chunk
+	var scratch *big.Int
+	for i := start; i < end; i++ {
+		if !isDigit(s[i]) {
+			continue
+		}
+		digit := uint64(s[i] - '0')
+		if scratch == nil {
+			if small <= (math.MaxUint64-digit)/10 {
+				small = small*10 + digit
+				continue
+			}
+			z.ensureInitialized()
+			z.integer.SetUint64(small)
+			scratch = getInt()
+		}
+		if size == _ChunkDigits {
+			z.integer.Mul(z.integer, pow10(size))
+			z.integer.Add(z.integer, scratch.SetUint64(chunk))
+			chunk, size = 0, 0
+		}
+		chunk = chunk*10 + digit
+		size++
+	}
+	switch {
+	case scratch != nil:
+		z.integer.Mul(z.integer, pow10(size))
+		z.integer.Add(z.integer, scratch.SetUint64(chunk))
+		putInt(scratch)
+		if negative {
+			z.integer.Neg(z.integer)
+		}
+	case small <= math.MaxInt64 || negative && small == 1<<63: // fits inline
+		z.small, z.inline = int64(small), true
+		if negative {
+			z.small = -z.small
+		}
+	default:
+		z.ensureInitialized()
+		z.integer.SetUint64(small)
+		if negative {
+			z.integer.Neg(z.integer)
+		}
+	}
+	z.exponent = int(exponent - int64(decimals))
+	return nil
+}
+
+// ParseDecimalWith returns a new decimal by parsing str with the grammar extended by opts and the limits of opts.
+// A *ParseError is returned if str is invalid or out of range, ErrInfinity or ErrNaN is returned for the special values.
+func ParseDecimalWith(str string, opts ParseOptions) (*Decimal, error) {
+	d := new(Decimal)
+	if err := setDecimal(d, str, opts); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// defaultParseOptions returns the options of ParseDecimal, SetString, SetBytes and UnmarshalText.
+func defaultParseOptions() ParseOptions {
+	return ParseOptions{Limits: DecimalLimits}
+}
@@ -0,0 +1,224 @@
+package big
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+// parseDecimalByPattern is the former regexp implementation of SetString (rejecting out of range exponents), used as a reference.
+func parseDecimalByPattern(str string) (string, int, bool) {
+	matches := regexp.MustCompile(`^([-+]?\d+)(\.(\d+))?([eE]([-+]?\d+))?$`).FindStringSubmatch(str)
+	if len(matches) != 6 {
+		return "", 0, false
+	}
+	decimals := strings.TrimRight(matches[3], "0")
+	exponent := len(decimals) * -1
+	if len(matches[5]) > 0 {
+		exp, err := strconv.ParseInt(matches[5], 10, 64)
+		if err != nil || exp < math.MinInt64+int64(len(decimals)) {
+			return "", 0, false
+		}
+		exponent += int(exp)
+	}
+	integer, _ := new(big.Int).SetString(matches[1]+decimals, 10)
+	return integer.String(), exponent, true
+}
+
+func TestParse(t *testing.T) {
+	defer func(l Limits) { DecimalLimits = l }(DecimalLimits)
+	DecimalLimits = Limits{} // opt out, the exponents of the reference pattern are unlimited
+
+	// test valid and invalid strings
+	data := []string{
+		"0", "-0", "+0", "00", "007", "1.0", "1.50", "-0.000", "123.456e7", "1E-7", "1e+7", "-1.5e-0",
+		"123456789012345678", "1234567890123456789", "12345678901234567890", "-123456789012345678901234567890.123456789",
+		"99999999999999999999999999999999999", "1.000000000000000000000000000001", "1e9223372036854775807",
+		"1e9223372036854775808", "1e-99999999999999999999999", "0.1e-9223372036854775808",
+		"", "+", "-", ".", "1.", ".5", "1..2", "1.2.3", "e5", "1e", "1e+", "1e5.5", "--1", "+-1", " 1", "1 ", "1_000",
+		"0x10", "1,5", "١", "Inf", "NaN", "1e5e5", "1.5f", "+.5",
+	}
+	for _, v := range data {
+		integer, exponent, ok := parseDecimalByPattern(v)
+		d, ok2 := new(Decimal).SetString(v)
+		testing2.AssertEqual(t, ok2, ok)
+		d2, ok3 := new(Decimal).SetBytes([]byte(v))
+		testing2.AssertEqual(t, ok3, ok)
+		if ok {
+			d.ensureInitialized()
+			d2.ensureInitialized()
+			testing2.AssertEqual(t, d.integer.String(), integer)
+			testing2.AssertEqual(t, d.exponent, exponent)
+			testing2.AssertEqual(t, d2.integer.String(), integer)
+			testing2.AssertEqual(t, d2.exponent, exponent)
+		}
+	}
+
+	// test random strings against the former implementation
+	random := rand.New(rand.NewSource(1))
+	alphabet := "0123456789.-+eE"
+	for i := 0; i < 10000; i++ {
+		buf := make([]byte, 1+random.Intn(45))
+		for j := range buf {
+			buf[j] = alphabet[random.Intn(len(alphabet))]
+		}
+		integer, exponent, ok := parseDecimalByPattern(string(buf))
+		d, ok2 := new(Decimal).SetBytes(buf)
+		testing2.AssertEqual(t, ok2, ok)
+		if ok {
+			d.ensureInitialized()
+			testing2.AssertEqual(t, d.integer.String(), integer)
+			testing2.AssertEqual(t, d.exponent, exponent)
+		}
+	}
+
+	// test ParseDecimalWith
+	data2 := map[string][5]string{ // strict, Underscores, BareDot, Whitespace, SpecialValues ("" means invalid)
+		"1.5":                       {"1.5", "1.5", "1.5", "1.5", "1.5"},
+		"1_000.000_5":               {"", "1000.0005", "", "", ""},
+		"1e1_0":                     {"", "10000000000", "", "", ""},
+		"1__0":                      {"", "", "", "", ""},
+		"_1":                        {"", "", "", "", ""},
+		"1_":                        {"", "", "", "", ""},
+		"1_.5":                      {"", "", "", "", ""},
+		"1._5":                      {"", "", "", "", ""},
+		".5":                        {"", "", "0.5", "", ""},
+		"5.":                        {"", "", "5", "", ""},
+		"+.5e3":                     {"", "", "500", "", ""},
+		"-5.e-1":                    {"", "", "-0.5", "", ""},
+		".":                         {"", "", "", "", ""},
+		"-.e1":                      {"", "", "", "", ""},
+		" \t1.5\n":                  {"", "", "", "1.5", ""},
+		" ":                         {"", "", "", "", ""},
+		"1 .5":                      {"", "", "", "", ""},
+		"- 1":                       {"", "", "", "", ""},
+		"Infinity":                  {"", "", "", "", "inf"},
+		"-inf":                      {"", "", "", "", "inf"},
+		"+NaN":                      {"", "", "", "", "nan"},
+		"infinit":                   {"", "", "", "", ""},
+		"1e9223372036854775807":     {"1e9223372036854775807", "", "", "", ""},
+		"1e9223372036854775808":     {"", "", "", "", ""},
+		"0.01e-9223372036854775807": {"", "", "", "", ""},
+	}
+	options := []ParseOptions{StrictParseOptions, {Underscores: true}, {BareDot: true}, {Whitespace: true}, {SpecialValues: true}}
+	for k, v := range data2 {
+		for i, opts := range options {
+			d, err := ParseDecimalWith(k, opts)
+			want := v[i]
+			if i > 0 && want == "" && v[0] != "" {
+				want = v[0]
+			}
+			switch want {
+			case "":
+				testing2.AssertNotEqual(t, err, nil)
+				testing2.AssertNotEqual(t, err, ErrInfinity)
+				testing2.AssertNotEqual(t, err, ErrNaN)
+			case "inf":
+				testing2.AssertEqual(t, err, ErrInfinity)
+			case "nan":
+				testing2.AssertEqual(t, err, ErrNaN)
+			default:
+				testing2.AssertEqual(t, err, nil)
+				testing2.AssertEqual(t, d.Cmp(MustParseDecimal(want)), 0)
+			}
+		}
+	}
+	d, err := ParseDecimalWith(" +1_234.500_0e-2\n", LenientParseOptions)
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, d.String(), "12.345")
+	_, err = ParseDecimal("1e99999999999999999999")
+	testing2.AssertNotEqual(t, err, nil)
+	for k, v := range map[string][3]string{ // LeadingSpaces, DecimalComma, NoExponent
+		"  1.5":  {"1.5", "", ""},
+		"1.5 ":   {"", "", ""},
+		"1,5":    {"", "1.5", ""},
+		"-1,5e1": {"", "-15", ""},
+		"1,":     {"", "", ""},
+		"1.5e1":  {"15", "15", ""},
+		"15":     {"15", "15", "15"},
+	} {
+		for i, opts := range []ParseOptions{{LeadingSpaces: true}, {DecimalComma: true}, {NoExponent: true}} {
+			d, err := ParseDecimalWith(k, opts)
+			if v[i] == "" {
+				testing2.AssertNotEqual(t, err, nil)
+			} else {
+				testing2.AssertEqual(t, err, nil)
+				testing2.AssertEqual(t, d.String(), v[i])
+			}
+		}
+	}
+
+	// test ParseError
+	data3 := map[string][2]int{ // offset, reason
+		"":                         {0, int(EmptyInput)},
+		"1.5x":                     {3, int(InvalidCharacter)},
+		"-":                        {1, int(InvalidCharacter)},
+		"x1":                       {0, int(InvalidCharacter)},
+		"1.":                       {2, int(InvalidCharacter)},
+		".5":                       {0, int(InvalidCharacter)},
+		"1e":                       {2, int(InvalidCharacter)},
+		"1e+x":                     {3, int(InvalidCharacter)},
+		"12_3":                     {2, int(InvalidCharacter)},
+		"1.5e99999999999999999999": {3, int(ExponentOutOfRange)},
+	}
+	for k, v := range data3 {
+		_, err := ParseDecimal(k)
+		var e *ParseError
+		testing2.AssertEqual(t, errors.As(err, &e), true)
+		testing2.AssertEqual(t, e.Input, k)
+		testing2.AssertEqual(t, e.Offset, v[0])
+		testing2.AssertEqual(t, int(e.Reason), v[1])
+	}
+	_, err = ParseDecimalWith("  1_2__3 ", LenientParseOptions)
+	testing2.AssertEqual(t, err.Error(), `decimal string "  1_2__3 " is invalid: invalid character '_' at offset 5`)
+	_, err = ParseDecimalWith(" \t", LenientParseOptions)
+	testing2.AssertEqual(t, err.(*ParseError).Reason, EmptyInput)
+	testing2.AssertEqual(t, err.Error(), `decimal string " \t" is invalid: empty input`)
+	err = new(Decimal).UnmarshalText([]byte("1e"))
+	testing2.AssertEqual(t, err.Error(), `decimal string "1e" is invalid: unexpected end of input`)
+	_, err = ParseDecimal("1e-9999999999999999999")
+	testing2.AssertEqual(t, err.Error(), `decimal string "1e-9999999999999999999" is invalid: exponent out of range at offset 1`)
+
+	// test unchanged on failure
+	d = MustParseDecimal("1.5")
+	_, ok := d.SetString("1.5x")
+	testing2.AssertEqual(t, ok, false)
+	testing2.AssertEqual(t, d.String(), "1.5")
+
+	// test allocations
+	str, buf := "-1234567.8900", []byte("123456789012345.678e-3")
+	allocs := testing.AllocsPerRun(100, func() {
+		d.SetString(str)
+		d.SetBytes(buf)
+	})
+	testing2.AssertEqual(t, allocs, float64(0))
+	testing2.AssertEqual(t, d.String(), "123456789012.345678")
+	long := "-123456789012345678901234567890123456789012345678901234567890.5"
+	d = MustParseDecimal(long)
+	allocs = testing.AllocsPerRun(100, func() {
+		d.SetString(long) // the big coefficient is reused
+	})
+	testing2.AssertEqual(t, allocs, float64(0))
+	testing2.AssertEqual(t, d.String(), long)
+
+	// test coefficients around the limits of int64 and uint64 (inline while fitting in int64)
+	for _, v := range []string{
+		"9223372036854775807", "-9223372036854775808", "9223372036854775808", "-9223372036854775809",
+		"18446744073709551615", "18446744073709551616", "-18446744073709551616", "00000000000000000000000000001.5",
+		"1234567890123456789012345678901234567", "123456789012345678901234567890123456",
+	} {
+		d := MustParseDecimal(v)
+		want, _ := new(big.Int).SetString(strings.Replace(strings.TrimLeft(v, "0"), ".", "", 1), 10)
+		buf := getInt()
+		testing2.AssertEqual(t, d.coefficient(buf).Cmp(want), 0)
+		testing2.AssertEqual(t, d.isInline(), want.IsInt64())
+		putInt(buf)
+	}
+}
@@ -0,0 +1,142 @@
+package big
+
+import (
+	"encoding/binary"
+	"math/big"
+	"strings"
+
+	"github.com/golang-plus/errors"
+)
+
+// sign codes of PostgreSQL binary NUMERIC.
+const (
+	_PostgresNumericPos  = 0x0000
+	_PostgresNumericNeg  = 0x4000
+	_PostgresNumericNaN  = 0xC000
+	_PostgresNumericPInf = 0xD000
+	_PostgresNumericNInf = 0xF000
+
+	_PostgresNumericMaxDScale = 0x3FFF
+)
+
+// AppendPostgresNumeric appends the PostgreSQL binary NUMERIC representation of d to dst and returns the extended buffer.
+// The layout is: int16 ndigits, int16 weight, uint16 sign, uint16 dscale followed by ndigits base-10000 digits (all big-endian).
+func AppendPostgresNumeric(dst []byte, d *Decimal) ([]byte, error) {
+	c := d.coefficient(new(big.Int))
+	dscale := 0
+	if d.exponent < 0 {
+		dscale = d.exponent * -1
+	}
+	if dscale > _PostgresNumericMaxDScale {
+		return nil, errors.Newf("decimal scale %d exceeds the display scale of PostgreSQL NUMERIC", dscale)
+	}
+	sign := _PostgresNumericPos
+	if c.Sign() < 0 {
+		sign = _PostgresNumericNeg
+	}
+	if c.Sign() == 0 {
+		return appendPostgresNumericHeader(dst, 0, 0, sign, dscale), nil
+	}
+
+	// align the coefficient to the base-10000 digit boundary
+	coefficient := new(big.Int).Abs(c)
+	fractions, zeros := 0, 0 // decimal digits after the point and groups of trailing zeros before the point
+	if d.exponent < 0 {
+		pad := (4 - dscale%4) % 4
+		coefficient.Mul(coefficient, pow10(pad))
+		fractions = dscale + pad
+	} else {
+		coefficient.Mul(coefficient, pow10(d.exponent%4))
+		zeros = d.exponent / 4
+	}
+	str := coefficient.String()
+	if r := len(str) % 4; r != 0 {
+		str = strings.Repeat("0", 4-r) + str
+	}
+	digits := make([]uint16, 0, len(str)/4)
+	for i := 0; i < len(str); i += 4 {
+		digits = append(digits, uint16(str[i]-'0')*1000+uint16(str[i+1]-'0')*100+uint16(str[i+2]-'0')*10+uint16(str[i+3]-'0'))
+	}
+	weight := len(digits) - 1 - fractions/4 + zeros
+	for digits[len(digits)-1] == 0 { // strip trailing zero digits
+		digits = digits[:len(digits)-1]
+	}
+	if len(digits) > 0x7FFF || weight > 0x7FFF || weight < -0x8000 {
+		return nil, errors.New("decimal exceeds the range of PostgreSQL NUMERIC")
+	}
+
+	dst = appendPostgresNumericHeader(dst, len(digits), weight, sign, dscale)
+	for _, digit := range digits {
+		dst = binary.BigEndian.AppendUint16(dst, digit)
+	}
+	return dst, nil
+}
+
+func appendPostgresNumericHeader(dst []byte, ndigits, weight, sign, dscale int) []byte {
+	dst = binary.BigEndian.AppendUint16(dst, uint16(ndigits))
+	dst = binary.BigEndian.AppendUint16(dst, uint16(int16(weight)))
+	dst = binary.BigEndian.AppendUint16(dst, uint16(sign))
+	return binary.BigEndian.AppendUint16(dst, uint16(dscale))
+}
+
+// EncodePostgresNumeric returns the PostgreSQL binary NUMERIC representation of d.
+func EncodePostgresNumeric(d *Decimal) ([]byte, error) {
+	return AppendPostgresNumeric(nil, d)
+}
+
+// DecodePostgresNumeric returns a new decimal by decoding PostgreSQL binary NUMERIC data.
+// The limits of DecimalLimits are enforced.
+// ErrNaN or ErrInfinity is returned for the special values.
+func DecodePostgresNumeric(data []byte) (*Decimal, error) {
+	if len(data) < 8 {
+		return nil, errors.New("PostgreSQL NUMERIC data is too short")
+	}
+	ndigits := int(int16(binary.BigEndian.Uint16(data[0:])))
+	weight := int(int16(binary.BigEndian.Uint16(data[2:])))
+	sign := binary.BigEndian.Uint16(data[4:])
+	dscale := binary.BigEndian.Uint16(data[6:])
+	switch sign {
+	case _PostgresNumericPos, _PostgresNumericNeg:
+	case _PostgresNumericNaN:
+		return nil, ErrNaN
+	case _PostgresNumericPInf, _PostgresNumericNInf:
+		return nil, ErrInfinity
+	default:
+		return nil, errors.Newf("PostgreSQL NUMERIC sign 0x%04X is invalid", sign)
+	}
+	if ndigits < 0 || len(data) != 8+ndigits*2 || dscale > _PostgresNumericMaxDScale {
+		return nil, errors.New("PostgreSQL NUMERIC data is invalid")
+	}
+
+	d := new(Decimal)
+	d.ensureInitialized()
+	if ndigits == 0 {
+		return d, nil
+	}
+	var small uint64 // accumulates up to 4 digits (16 decimal digits) without big.Int
+	base := big.NewInt(10000)
+	for i := 0; i < ndigits; i++ {
+		digit := binary.BigEndian.Uint16(data[8+i*2:])
+		if digit >= 10000 {
+			return nil, errors.Newf("PostgreSQL NUMERIC digit %d is invalid", digit)
+		}
+		if i < 4 {
+			small = small*10000 + uint64(digit)
+			if i == 3 || i == ndigits-1 {
+				d.integer.SetUint64(small)
+			}
+			continue
+		}
+		d.integer.Mul(d.integer, base)
+		d.integer.Add(d.integer, big.NewInt(int64(digit)))
+	}
+	if sign == _PostgresNumericNeg {
+		d.integer.Neg(d.integer)
+	}
+	d.exponent = (weight - ndigits + 1) * 4
+	d.trimFraction()
+	if err := DecimalLimits.Check(d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
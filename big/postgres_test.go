@@ -0,0 +1,70 @@
+package big
+
+import (
+	"testing"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+func TestPostgresNumeric(t *testing.T) {
+	// test EncodePostgresNumeric DecodePostgresNumeric
+	data := map[string][]byte{
+		"0":          {0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		"1.5":        {0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x01, 0x13, 0x88},
+		"-12345.678": {0x00, 0x03, 0x00, 0x01, 0x40, 0x00, 0x00, 0x03, 0x00, 0x01, 0x09, 0x29, 0x1A, 0x7C},
+		"0.0001":     {0x00, 0x01, 0xFF, 0xFF, 0x00, 0x00, 0x00, 0x04, 0x00, 0x01},
+		"10000000":   {0x00, 0x01, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x03, 0xE8},
+		"1e20":       {0x00, 0x01, 0x00, 0x05, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01},
+	}
+	for k, v := range data {
+		b, err := EncodePostgresNumeric(MustParseDecimal(k))
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, b, v)
+		d, err := DecodePostgresNumeric(v)
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.Cmp(MustParseDecimal(k)), 0)
+	}
+
+	// test round trip
+	for _, v := range []string{
+		"123456789012345678901234567890.123456789012345678901",
+		"-0.000000000000000000000000000000001",
+		"99999999.99999999",
+		"-1234567890123456",
+		"12345678901234567",
+		"3.33333333333333333333",
+	} {
+		b, err := EncodePostgresNumeric(MustParseDecimal(v))
+		testing2.AssertEqual(t, err, nil)
+		d, err := DecodePostgresNumeric(b)
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.String(), v)
+	}
+
+	// test trailing zeros within the display scale
+	d, err := DecodePostgresNumeric([]byte{0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x00, 0x01, 0x13, 0x88}) // 1.50
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, d.String(), "1.5")
+
+	// test special values
+	_, err = DecodePostgresNumeric([]byte{0x00, 0x00, 0x00, 0x00, 0xC0, 0x00, 0x00, 0x00})
+	testing2.AssertEqual(t, err, ErrNaN)
+	_, err = DecodePostgresNumeric([]byte{0x00, 0x00, 0x00, 0x00, 0xD0, 0x00, 0x00, 0x00})
+	testing2.AssertEqual(t, err, ErrInfinity)
+	_, err = DecodePostgresNumeric([]byte{0x00, 0x00, 0x00, 0x00, 0xF0, 0x00, 0x00, 0x00})
+	testing2.AssertEqual(t, err, ErrInfinity)
+
+	// test invalid data
+	for _, v := range [][]byte{
+		nil,
+		{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		{0x00, 0x00, 0x00, 0x00, 0x12, 0x34, 0x00, 0x00},
+		{0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		{0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x27, 0x10},
+	} {
+		_, err := DecodePostgresNumeric(v)
+		testing2.AssertNotEqual(t, err, nil)
+	}
+	_, err = EncodePostgresNumeric(MustParseDecimal("1e-20000"))
+	testing2.AssertNotEqual(t, err, nil)
+}
@@ -0,0 +1,218 @@
+package big
+
+import (
+	"math"
+	"strings"
+
+	"github.com/golang-plus/errors"
+)
+
+// The sortable key of a decimal is: a marker byte ordering NaN < -Inf < negative < zero < positive < +Inf,
+// then for non-zero finite values the adjusted exponent E (value = ±0.d1d2...dn * 10**E, d1 != 0, dn != 0) as an order-preserving varint
+// and the digits as nibbles (digit+1) terminated by a zero nibble.
+// The exponent and digits of negative values are complemented, so byte-wise comparison of keys matches Cmp.
+const (
+	_SortableNaN      = 0x01
+	_SortableNegInf   = 0x02
+	_SortableNegative = 0x03
+	_SortableZero     = 0x04
+	_SortablePositive = 0x05
+	_SortablePosInf   = 0x06
+)
+
+// AppendSortableKey appends the order-preserving key of d to dst and returns the extended buffer.
+// Keys of equal decimals (e.g. 1.0 and 1.00) are equal, byte-wise comparison of keys matches Cmp.
+func (d *Decimal) AppendSortableKey(dst []byte) []byte {
+	buf := getInt()
+	defer putInt(buf)
+	c := d.coefficient(buf)
+	sign := c.Sign()
+	if sign == 0 {
+		return append(dst, _SortableZero)
+	}
+	digits := c.String()
+	if sign < 0 {
+		digits = digits[1:]
+	}
+	trimmed := strings.TrimRight(digits, "0")
+	// the adjusted exponent exceeds int64 (as uint64) for the exponents near the max int
+	exp, negExp := uint64(int64(d.exponent))+uint64(len(digits)), false
+	if e := int64(d.exponent) + int64(len(digits)); d.exponent < 0 && e < 0 {
+		exp, negExp = ^uint64(e), true
+	}
+
+	if sign < 0 {
+		dst = append(dst, _SortableNegative)
+	} else {
+		dst = append(dst, _SortablePositive)
+	}
+	body := len(dst)
+	dst = appendSortableVarint(dst, exp, negExp)
+	for i := 0; i < len(trimmed); i += 2 {
+		b := (trimmed[i] - '0' + 1) << 4
+		if i+1 < len(trimmed) {
+			b |= trimmed[i+1] - '0' + 1
+		}
+		dst = append(dst, b)
+	}
+	if len(trimmed)%2 == 0 {
+		dst = append(dst, 0x00) // terminator
+	}
+	if sign < 0 {
+		for i := body; i < len(dst); i++ {
+			dst[i] = ^dst[i]
+		}
+	}
+	return dst
+}
+
+// appendSortableVarint appends the order-preserving encoding of the integer u (non-negative) or int64(^u) (negative) to dst:
+// a length byte (0x80+n for non-negative, 0x7F-n for negative) followed by n big-endian bytes of u (complemented for negative).
+func appendSortableVarint(dst []byte, u uint64, negative bool) []byte {
+	n := 0
+	for v := u; v != 0; v >>= 8 {
+		n++
+	}
+	if negative {
+		dst = append(dst, byte(0x7F-n))
+	} else {
+		dst = append(dst, byte(0x80+n))
+	}
+	for i := n - 1; i >= 0; i-- {
+		b := byte(u >> (uint(i) * 8))
+		if negative {
+			b = ^b
+		}
+		dst = append(dst, b)
+	}
+	return dst
+}
+
+// AppendSortableInf appends the order-preserving key of positive infinity (sign >= 0) or negative infinity (sign < 0) to dst.
+func AppendSortableInf(dst []byte, sign int) []byte {
+	if sign < 0 {
+		return append(dst, _SortableNegInf)
+	}
+	return append(dst, _SortablePosInf)
+}
+
+// AppendSortableNaN appends the order-preserving key of NaN (less than all other keys) to dst.
+func AppendSortableNaN(dst []byte) []byte {
+	return append(dst, _SortableNaN)
+}
+
+// DecodeSortableKey returns a new decimal by decoding the order-preserving key at the beginning of key and the rest of key.
+// ErrNaN or ErrInfinity is returned (with the rest of key) for the special values.
+// Only the keys produced by AppendSortableKey are accepted (no leading or trailing zero digit, minimal exponent bytes).
+// The limits of DecimalLimits are enforced.
+func DecodeSortableKey(key []byte) (*Decimal, []byte, error) {
+	if len(key) == 0 {
+		return nil, nil, errors.New("sortable key is empty")
+	}
+	switch key[0] {
+	case _SortableZero:
+		return new(Decimal).SetInt64(0), key[1:], nil
+	case _SortableNaN:
+		return nil, key[1:], ErrNaN
+	case _SortableNegInf, _SortablePosInf:
+		return nil, key[1:], ErrInfinity
+	case _SortableNegative, _SortablePositive:
+	default:
+		return nil, nil, errors.Newf("sortable key marker 0x%02X is invalid", key[0])
+	}
+	negative := key[0] == _SortableNegative
+	next := func(i int) byte {
+		if negative {
+			return ^key[i]
+		}
+		return key[i]
+	}
+
+	// exponent
+	if len(key) < 2 {
+		return nil, nil, errors.New("sortable key is truncated")
+	}
+	prefix := next(1)
+	n := int(prefix) - 0x80
+	if prefix < 0x80 {
+		n = 0x7F - int(prefix)
+	}
+	if n > 8 || len(key) < 2+n {
+		return nil, nil, errors.New("sortable key exponent is invalid")
+	}
+	var u uint64
+	for i := 0; i < n; i++ {
+		b := next(2 + i)
+		if prefix < 0x80 {
+			b = ^b
+		}
+		if i == 0 && b == 0 { // not minimal
+			return nil, nil, errors.New("sortable key exponent is invalid")
+		}
+		u = u<<8 | uint64(b)
+	}
+
+	// digits
+	var digits strings.Builder
+	if negative {
+		digits.WriteByte('-')
+	}
+	i := 2 + n
+	for done := false; !done; i++ {
+		if i >= len(key) {
+			return nil, nil, errors.New("sortable key is truncated")
+		}
+		b := next(i)
+		for _, nibble := range []byte{b >> 4, b & 0x0F} {
+			if nibble == 0 {
+				if b&0x0F != 0 { // a digit after the terminator
+					return nil, nil, errors.New("sortable key digit is invalid")
+				}
+				done = true
+				break
+			}
+			if nibble > 10 {
+				return nil, nil, errors.New("sortable key digit is invalid")
+			}
+			digits.WriteByte('0' + nibble - 1)
+		}
+	}
+	str := digits.String()
+	count := len(str)
+	if negative {
+		count--
+	}
+	if count == 0 {
+		return nil, nil, errors.New("sortable key has no digits")
+	}
+	if str[len(str)-count] == '0' || str[len(str)-1] == '0' { // not normalized
+		return nil, nil, errors.New("sortable key digit is invalid")
+	}
+	var exp int64 // the adjusted exponent (u or int64(^u)) minus count
+	if prefix >= 0x80 {
+		if u > math.MaxInt64+uint64(count) {
+			return nil, nil, errors.New("sortable key exponent is out of range")
+		}
+		exp = int64(u - uint64(count))
+	} else {
+		if int64(^u) >= 0 || int64(^u) < math.MinInt64+int64(count) {
+			return nil, nil, errors.New("sortable key exponent is out of range")
+		}
+		exp = int64(^u) - int64(count)
+	}
+	if int64(int(exp)) != exp {
+		return nil, nil, errors.New("sortable key exponent is out of range")
+	}
+	if DecimalLimits.MaxDigits > 0 && count > DecimalLimits.MaxDigits { // before converting the digits
+		return nil, nil, errors.Newf("%d digits of sortable key exceeds the limit %d", count, DecimalLimits.MaxDigits)
+	}
+	d := new(Decimal)
+	d.ensureInitialized()
+	d.integer.SetString(str, 10)
+	d.exponent = int(exp)
+	d.demote()
+	if err := DecimalLimits.Check(d); err != nil {
+		return nil, nil, err
+	}
+	return d, key[i:], nil
+}
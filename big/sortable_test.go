@@ -0,0 +1,104 @@
+package big
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"testing"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+func TestSortableKey(t *testing.T) {
+	// test order
+	values := []string{
+		"-1e300", "-123456789012345678901234567890", "-1000", "-999.99", "-100", "-99", "-10.5", "-10", "-9",
+		"-1.0000000000000000000001", "-1", "-0.99", "-0.1234", "-0.123", "-0.12", "-0.1", "-0.01", "-1e-300",
+		"0",
+		"1e-300", "0.01", "0.1", "0.12", "0.123", "0.1234", "0.99", "1", "1.0000000000000000000001",
+		"9", "10", "10.5", "99", "100", "999.99", "1000", "123456789012345678901234567890", "1e300",
+	}
+	keys := make([][]byte, 0, len(values)+3)
+	keys = append(keys, AppendSortableNaN(nil), AppendSortableInf(nil, -1))
+	for _, v := range values {
+		keys = append(keys, MustParseDecimal(v).AppendSortableKey(nil))
+	}
+	keys = append(keys, AppendSortableInf(nil, 1))
+	testing2.AssertEqual(t, sort.SliceIsSorted(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 }), true)
+	for i := 1; i < len(keys); i++ {
+		testing2.AssertEqual(t, bytes.Compare(keys[i-1], keys[i]), -1)
+	}
+
+	// test equal values with different scales
+	testing2.AssertEqual(t, MustParseDecimal("1.0").AppendSortableKey(nil), MustParseDecimal("1.00").AppendSortableKey(nil))
+	testing2.AssertEqual(t, MustParseDecimal("100").AppendSortableKey(nil), MustParseDecimal("1e2").AppendSortableKey(nil))
+	testing2.AssertEqual(t, new(Decimal).Mul(MustParseDecimal("1.5")).AppendSortableKey(nil), MustParseDecimal("-0").AppendSortableKey(nil))
+	testing2.AssertEqual(t, MustParseDecimal("-2.50").AppendSortableKey(nil), new(Decimal).Add(MustParseDecimal("-25")).Quo(MustParseDecimal("10")).AppendSortableKey(nil))
+
+	// test DecodeSortableKey
+	var composite []byte
+	for _, v := range values {
+		composite = MustParseDecimal(v).AppendSortableKey(composite)
+	}
+	composite = AppendSortableInf(composite, 1)
+	composite = append(composite, "suffix"...)
+	for _, v := range values {
+		d, rest, err := DecodeSortableKey(composite)
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.String(), MustParseDecimal(v).String())
+		composite = rest
+	}
+	_, rest, err := DecodeSortableKey(composite)
+	testing2.AssertEqual(t, err, ErrInfinity)
+	testing2.AssertEqual(t, string(rest), "suffix")
+	_, _, err = DecodeSortableKey(AppendSortableNaN(nil))
+	testing2.AssertEqual(t, err, ErrNaN)
+
+	// test invalid keys
+	key := MustParseDecimal("-123.45").AppendSortableKey(nil)
+	for _, v := range [][]byte{nil, {0x00}, {0x07}, {0x05}, key[:len(key)-1], {0x05, 0x81, 0x01, 0xF0}, {0x05, 0x8A}} {
+		_, _, err := DecodeSortableKey(v)
+		testing2.AssertNotEqual(t, err, nil)
+	}
+	testing2.AssertEqual(t, MustParseDecimal("1").AppendSortableKey(nil), []byte{0x05, 0x81, 0x01, 0x20})
+	ones := append([]byte{0x05, 0x88}, bytes.Repeat([]byte{0xFF}, 8)...)
+	for _, v := range [][]byte{ // not canonical or out of range
+		{0x05, 0x81, 0x01, 0x21, 0x00},                                     // trailing zero digit
+		{0x05, 0x81, 0x02, 0x12, 0x00},                                     // leading zero digit
+		{0x05, 0x82, 0x00, 0x01, 0x20},                                     // exponent not minimal
+		{0x05, 0x81, 0x00, 0x20},                                           // exponent not minimal
+		{0x05, 0x81, 0x01, 0x02},                                           // digit after the terminator
+		append(append([]byte{0x05, 0x77, 0x7F}, make([]byte, 7)...), 0x20), // non-negative exponent as negative
+		append(ones, 0x20),                                                 // exponent overflows int
+	} {
+		_, _, err := DecodeSortableKey(v)
+		testing2.AssertNotEqual(t, err, nil)
+	}
+
+	// test exponents near the limits of int (the adjusted exponent overflows int64)
+	defer func(l Limits) { DecimalLimits = l }(DecimalLimits)
+	DecimalLimits = Limits{} // opt out, the exponents exceed the default limits
+	huge := []*Decimal{
+		{small: -12, inline: true, exponent: math.MaxInt},
+		{small: -1, inline: true, exponent: math.MaxInt},
+		{small: -1, inline: true, exponent: math.MaxInt - 5},
+		{small: -1, inline: true, exponent: math.MinInt},
+		{small: 1, inline: true, exponent: math.MinInt},
+		{small: 12, inline: true, exponent: math.MinInt},
+		{small: 1, inline: true},
+		{small: 1, inline: true, exponent: math.MaxInt - 5},
+		{small: 1, inline: true, exponent: math.MaxInt},
+		{small: 12, inline: true, exponent: math.MaxInt},
+	}
+	for i, v := range huge {
+		key := v.AppendSortableKey(nil)
+		if i > 0 {
+			testing2.AssertEqual(t, bytes.Compare(huge[i-1].AppendSortableKey(nil), key), -1)
+		}
+		d, rest, err := DecodeSortableKey(key)
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, len(rest), 0)
+		testing2.AssertEqual(t, d.small, v.small)
+		testing2.AssertEqual(t, d.exponent, v.exponent)
+	}
+}
@@ -0,0 +1,68 @@
+package big
+
+import (
+	"database/sql/driver"
+	"math"
+
+	"github.com/golang-plus/errors"
+)
+
+// Scan implements the sql.Scanner interface.
+// Supported sources are string, []byte, int64 and float64, use NullDecimal for nullable columns.
+func (d *Decimal) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case []byte:
+		return d.UnmarshalText(v)
+	case int64:
+		d.SetInt64(v)
+		return nil
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return ErrNaN
+		case math.IsInf(v, 0):
+			return ErrInfinity
+		}
+		d.SetFloat64(v)
+		return nil
+	case nil:
+		return errors.New("cannot scan NULL into decimal (use NullDecimal instead)")
+	}
+	return errors.Newf("cannot scan type %T into decimal", src)
+}
+
+// Value implements the driver.Valuer interface.
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// NullDecimal represents a decimal that may be null.
+type NullDecimal struct {
+	Decimal Decimal
+	Valid   bool // Valid is true if Decimal is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullDecimal) Scan(src interface{}) error {
+	if src == nil {
+		n.Decimal.SetInt64(0)
+		n.Valid = false
+		return nil
+	}
+	if err := n.Decimal.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullDecimal) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Decimal.Value()
+}
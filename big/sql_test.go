@@ -0,0 +1,55 @@
+package big
+
+import (
+	"database/sql/driver"
+	"math"
+	"testing"
+	"time"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+func TestSQL(t *testing.T) {
+	// test Scan
+	data := map[string]interface{}{
+		"123456789012345678901234567890.12": "123456789012345678901234567890.12",
+		"-0.001":                            []byte("-0.001"),
+		"42":                                int64(42),
+		"-19.99":                            float64(-19.99),
+	}
+	for k, v := range data {
+		d := new(Decimal)
+		testing2.AssertEqual(t, d.Scan(v), nil)
+		testing2.AssertEqual(t, d.String(), k)
+	}
+	for _, v := range []interface{}{nil, "abc", []byte(""), true, time.Now()} {
+		testing2.AssertNotEqual(t, new(Decimal).Scan(v), nil)
+	}
+	d := MustParseDecimal("1.5")
+	testing2.AssertEqual(t, d.Scan(math.NaN()), ErrNaN)
+	testing2.AssertEqual(t, d.Scan(math.Inf(-1)), ErrInfinity)
+	testing2.AssertEqual(t, d.String(), "1.5")
+
+	// test Value
+	v, err := MustParseDecimal("-123.4500").Value()
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, v, driver.Value("-123.45"))
+	var valuer driver.Valuer = MustParseDecimal("1")
+	testing2.AssertNotEqual(t, valuer, nil)
+
+	// test NullDecimal
+	var n NullDecimal
+	testing2.AssertEqual(t, n.Scan(nil), nil)
+	testing2.AssertEqual(t, n.Valid, false)
+	v, err = n.Value()
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, v, nil)
+	testing2.AssertEqual(t, n.Scan([]byte("9.87654321")), nil)
+	testing2.AssertEqual(t, n.Valid, true)
+	testing2.AssertEqual(t, n.Decimal.String(), "9.87654321")
+	v, err = n.Value()
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, v, driver.Value("9.87654321"))
+	testing2.AssertNotEqual(t, n.Scan("x"), nil)
+	testing2.AssertEqual(t, n.Valid, false)
+}
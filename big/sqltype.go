@@ -0,0 +1,93 @@
+package big
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang-plus/errors"
+)
+
+// DecimalType describes a SQL DECIMAL(precision, scale) column type.
+type DecimalType struct {
+	Precision int // total number of digits
+	Scale     int // number of digits after the decimal point
+}
+
+// String returns the SQL type name of t (e.g. "DECIMAL(10,2)").
+func (t DecimalType) String() string {
+	return fmt.Sprintf("DECIMAL(%d,%d)", t.Precision, t.Scale)
+}
+
+func (t DecimalType) check() error {
+	if t.Precision < 1 || t.Scale < 0 || t.Scale > t.Precision {
+		return errors.Newf("%s is invalid", t)
+	}
+	return nil
+}
+
+// fits reports whether the integer part of d (d has at most t.Scale decimal digits) fits t.
+func (t DecimalType) fits(d *Decimal) bool {
+	_, _, fits := d.scaledIntegerWithin(t.Precision, t.Scale)
+	return fits
+}
+
+// Validate returns an error if d does not fit t without rounding (more decimal digits than the scale or more integer digits than precision-scale).
+func (t DecimalType) Validate(d *Decimal) error {
+	if err := t.check(); err != nil {
+		return err
+	}
+	_, exact, fits := d.scaledIntegerWithin(t.Precision, t.Scale)
+	if !exact {
+		return errors.Newf("decimal has more than %d decimal digits for %s", t.Scale, t)
+	}
+	if !fits {
+		return errors.Newf("decimal overflows %s", t)
+	}
+	return nil
+}
+
+// Coerce returns a new decimal of d rounded to the scale of t with given rounding mode.
+// An error is returned if the rounded value overflows t. d is not changed.
+func (t DecimalType) Coerce(d *Decimal, mode RoundingMode) (*Decimal, error) {
+	if err := t.check(); err != nil {
+		return nil, err
+	}
+	z := new(Decimal).Copy(d).RoundWithMode(uint(t.Scale), mode)
+	if !t.fits(z) {
+		return nil, errors.Newf("decimal overflows %s", t)
+	}
+	return z, nil
+}
+
+// Format returns the string of d with exactly the scale of t decimal digits (e.g. "123.40" for DECIMAL(10,2)).
+// d must be valid for t.
+func (t DecimalType) Format(d *Decimal) (string, error) {
+	if err := t.Validate(d); err != nil {
+		return "", err
+	}
+	scaled, _ := d.scaledInteger(t.Scale)
+	var buf strings.Builder
+	if scaled.Sign() < 0 {
+		buf.WriteString("-")
+	}
+	str := scaled.Abs(scaled).String()
+	if len(str) <= t.Scale {
+		str = strings.Repeat("0", t.Scale-len(str)+1) + str
+	}
+	buf.WriteString(str[:len(str)-t.Scale])
+	if t.Scale > 0 {
+		buf.WriteString(".")
+		buf.WriteString(str[len(str)-t.Scale:])
+	}
+	return buf.String(), nil
+}
+
+// SQLLiteral returns the SQL literal of d cast to t (e.g. "CAST('123.45' AS DECIMAL(10,2))").
+// d must be valid for t, the literal contains only digits, sign and decimal point so it is safe to embed in SQL.
+func (t DecimalType) SQLLiteral(d *Decimal) (string, error) {
+	str, err := t.Format(d)
+	if err != nil {
+		return "", err
+	}
+	return "CAST('" + str + "' AS " + t.String() + ")", nil
+}
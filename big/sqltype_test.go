@@ -0,0 +1,106 @@
+package big
+
+import (
+	"testing"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+func TestDecimalType(t *testing.T) {
+	typ := DecimalType{Precision: 10, Scale: 2}
+	testing2.AssertEqual(t, typ.String(), "DECIMAL(10,2)")
+
+	// test Validate
+	for _, v := range []string{"0", "123.45", "-12345678.99", "99999999.99", "0.1", "1e7"} {
+		testing2.AssertEqual(t, typ.Validate(MustParseDecimal(v)), nil)
+	}
+	for _, v := range []string{"123.456", "100000000", "-100000000", "1e8", "0.001"} {
+		testing2.AssertNotEqual(t, typ.Validate(MustParseDecimal(v)), nil)
+	}
+	for _, v := range []DecimalType{{0, 0}, {5, 6}, {5, -1}} {
+		testing2.AssertNotEqual(t, v.Validate(MustParseDecimal("1")), nil)
+	}
+
+	// test Coerce
+	data := map[string][4]string{ // ToNearestEven, ToNearestAway, ToZero, AwayFromZero
+		"123.455":  {"123.46", "123.46", "123.45", "123.46"},
+		"123.445":  {"123.44", "123.45", "123.44", "123.45"},
+		"-0.005":   {"0", "-0.01", "0", "-0.01"},
+		"1.5":      {"1.5", "1.5", "1.5", "1.5"},
+		"99.99999": {"100", "100", "99.99", "100"},
+	}
+	for k, v := range data {
+		for i, mode := range []RoundingMode{ToNearestEven, ToNearestAway, ToZero, AwayFromZero} {
+			d := MustParseDecimal(k)
+			z, err := typ.Coerce(d, mode)
+			testing2.AssertEqual(t, err, nil)
+			testing2.AssertEqual(t, z.String(), v[i])
+			testing2.AssertEqual(t, d.String(), k) // unchanged
+		}
+	}
+	_, err := typ.Coerce(MustParseDecimal("99999999.995"), ToNearestEven)
+	testing2.AssertNotEqual(t, err, nil)
+	z, err := typ.Coerce(MustParseDecimal("99999999.995"), ToZero)
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, z.String(), "99999999.99")
+
+	// test Format SQLLiteral
+	data2 := map[string]string{
+		"123.45": "CAST('123.45' AS DECIMAL(10,2))",
+		"123.4":  "CAST('123.40' AS DECIMAL(10,2))",
+		"-0.05":  "CAST('-0.05' AS DECIMAL(10,2))",
+		"0":      "CAST('0.00' AS DECIMAL(10,2))",
+		"1e3":    "CAST('1000.00' AS DECIMAL(10,2))",
+	}
+	for k, v := range data2 {
+		str, err := typ.SQLLiteral(MustParseDecimal(k))
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, str, v)
+	}
+	str, err := DecimalType{Precision: 5, Scale: 0}.Format(MustParseDecimal("-12345"))
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, str, "-12345")
+	str, err = DecimalType{Precision: 3, Scale: 3}.Format(MustParseDecimal("0.5"))
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, str, "0.500")
+	_, err = typ.SQLLiteral(MustParseDecimal("0.001"))
+	testing2.AssertNotEqual(t, err, nil)
+
+	// test huge exponents rejected without scaling (and without the value in the error)
+	defer func(l Limits) { DecimalLimits = l }(DecimalLimits)
+	DecimalLimits = Limits{} // opt out, the exponents exceed the default limits
+
+	for k, v := range map[string]bool{"1e30000000": true, "-1e-30000000": false, "123e-30000000": false} { // overflows
+		huge := MustParseDecimal(k)
+		err := typ.Validate(huge)
+		testing2.AssertNotEqual(t, err, nil)
+		testing2.AssertEqual(t, len(err.Error()) < 100, true)
+		_, err = EncodeAvroDecimal(huge, 10, 2)
+		testing2.AssertNotEqual(t, err, nil)
+		z, err := typ.Coerce(huge, ToNearestEven)
+		testing2.AssertEqual(t, err != nil, v)
+		if err == nil {
+			testing2.AssertEqual(t, z.IsZero(), true) // rounded without scaling
+		}
+	}
+
+	// test RoundWithMode
+	testing2.AssertEqual(t, MustParseDecimal("2.5").RoundWithMode(0, ToNearestEven).String(), "2")
+	testing2.AssertEqual(t, MustParseDecimal("2.5").RoundWithMode(0, ToNearestAway).String(), "3")
+	testing2.AssertEqual(t, MustParseDecimal("-2.5").RoundWithMode(0, ToZero).String(), "-2")
+	testing2.AssertEqual(t, MustParseDecimal("-2.1").RoundWithMode(0, AwayFromZero).String(), "-3")
+	data3 := map[string]string{ // unknown mode rounds to nearest, ties to even
+		"2.5":                               "2",
+		"3.5":                               "4",
+		"-2.6":                              "-3",
+		"123456789012345678901234567890.5":  "123456789012345678901234567890",
+		"123456789012345678901234567891.5":  "123456789012345678901234567892",
+		"-123456789012345678901234567890.6": "-123456789012345678901234567891",
+	}
+	for k, v := range data3 {
+		testing2.AssertEqual(t, MustParseDecimal(k).RoundWithMode(0, RoundingMode(99)).String(), v)
+	}
+	z, err = typ.Coerce(MustParseDecimal("1.235"), RoundingMode(99))
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, z.String(), "1.24")
+}
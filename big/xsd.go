@@ -0,0 +1,152 @@
+package big
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/golang-plus/errors"
+)
+
+var (
+	// lexical space of xs:decimal (XML Schema Part 2: 3.2.3): [-+]?(\d+(\.\d*)?|\.\d+)
+	_XSDDecimalParseOptions = ParseOptions{BareDot: true, NoExponent: true}
+
+	// ISO 6093 numerical representations (with optional leading spaces and decimal mark "." or ",").
+	// NR1 has no decimal mark, NR2 and NR3 require it, and only NR3 has the exponent.
+	_NR12ParseOptions = ParseOptions{BareDot: true, LeadingSpaces: true, DecimalComma: true, NoExponent: true}
+	_NR3ParseOptions  = ParseOptions{BareDot: true, LeadingSpaces: true, DecimalComma: true}
+)
+
+// ParseXSDDecimal returns a new decimal by parsing the xs:decimal lexical form str (no exponent, no whitespace).
+// A *ParseError is returned if str is invalid or out of the limits of DecimalLimits.
+func ParseXSDDecimal(str string) (*Decimal, error) {
+	opts := _XSDDecimalParseOptions
+	opts.Limits = DecimalLimits
+	return ParseDecimalWith(str, opts)
+}
+
+// XSDDecimal returns the canonical xs:decimal representation of d:
+// the decimal point is required, leading and trailing zeros are prohibited except one digit on each side of the point (e.g. "1.0", "-0.5").
+func (d *Decimal) XSDDecimal() string {
+	str := d.String()
+	if !strings.Contains(str, ".") {
+		str += ".0"
+	}
+	return str
+}
+
+// ASN.1 REAL (X.690 8.5) constants.
+const (
+	_ASN1TagReal = 0x09
+
+	_ASN1RealNR1 = 0x01
+	_ASN1RealNR2 = 0x02
+	_ASN1RealNR3 = 0x03
+
+	_ASN1RealPlusInfinity  = 0x40
+	_ASN1RealMinusInfinity = 0x41
+	_ASN1RealNaN           = 0x42
+	_ASN1RealMinusZero     = 0x43
+)
+
+// MarshalASN1Real returns the DER encoding (tag, length and contents) of d as ASN.1 REAL in base-10 NR3 form (X.690 11.3.2),
+// e.g. 1 is "1.E+0", -123.4 is "-1234.E-1" and zero has no contents octets.
+func MarshalASN1Real(d *Decimal) []byte {
+	buf := getInt()
+	defer putInt(buf)
+	var contents []byte
+	if c := d.coefficient(buf); c.Sign() != 0 {
+		mantissa := c.String()
+		trimmed := strings.TrimRight(mantissa, "0")
+		exponent := d.exponent + len(mantissa) - len(trimmed)
+		contents = append(contents, _ASN1RealNR3)
+		contents = append(contents, trimmed...)
+		contents = append(contents, ".E"...)
+		if exponent >= 0 {
+			contents = append(contents, '+')
+		}
+		contents = strconv.AppendInt(contents, int64(exponent), 10)
+	}
+	dst := appendASN1Length([]byte{_ASN1TagReal}, len(contents))
+	return append(dst, contents...)
+}
+
+// appendASN1Length appends the DER length octets of n (short form if less than 0x80, otherwise the minimal long form).
+func appendASN1Length(dst []byte, n int) []byte {
+	if n < 0x80 {
+		return append(dst, byte(n))
+	}
+	size := 0
+	for v := n; v > 0; v >>= 8 {
+		size++
+	}
+	dst = append(dst, 0x80|byte(size))
+	for i := size - 1; i >= 0; i-- {
+		dst = append(dst, byte(n>>(uint(i)*8)))
+	}
+	return dst
+}
+
+// UnmarshalASN1Real returns a new decimal by decoding the BER/DER encoding (tag, length and contents) of ASN.1 REAL in base-10 (NR1, NR2 or NR3) form.
+// The numeral is validated strictly by its declared form, ErrNaN or ErrInfinity is returned for the special values.
+func UnmarshalASN1Real(data []byte) (*Decimal, error) {
+	if len(data) < 2 || data[0] != _ASN1TagReal {
+		return nil, errors.New("ASN.1 data is not a REAL")
+	}
+	length, n := int(data[1]), 2
+	if data[1] >= 0x80 {
+		size := int(data[1] & 0x7F)
+		if size == 0 || size > 4 || len(data) < 2+size {
+			return nil, errors.New("ASN.1 REAL length is invalid")
+		}
+		length = 0
+		for _, b := range data[2 : 2+size] {
+			length = length<<8 | int(b)
+		}
+		if length < 0 { // overflows int
+			return nil, errors.New("ASN.1 REAL length is invalid")
+		}
+		n += size
+	}
+	if len(data) != n+length {
+		return nil, errors.New("ASN.1 REAL length is invalid")
+	}
+	contents := data[n:]
+	if len(contents) == 0 {
+		return new(Decimal).SetInt64(0), nil
+	}
+
+	switch contents[0] {
+	case _ASN1RealPlusInfinity, _ASN1RealMinusInfinity:
+		return nil, ErrInfinity
+	case _ASN1RealNaN:
+		return nil, ErrNaN
+	case _ASN1RealMinusZero:
+		return new(Decimal).SetInt64(0), nil
+	}
+	if contents[0]&0xC0 != 0 {
+		return nil, errors.New("ASN.1 REAL is not in base-10 form")
+	}
+	numeral := string(contents[1:])
+	opts := _NR12ParseOptions
+	hasMark := strings.ContainsAny(numeral, ".,")
+	switch contents[0] {
+	case _ASN1RealNR1:
+		if hasMark {
+			return nil, errors.Newf("ASN.1 REAL numeral %q is invalid for NR1", numeral)
+		}
+	case _ASN1RealNR2:
+		if !hasMark {
+			return nil, errors.Newf("ASN.1 REAL numeral %q is invalid for NR2", numeral)
+		}
+	case _ASN1RealNR3:
+		if !hasMark || !strings.ContainsAny(numeral, "eE") {
+			return nil, errors.Newf("ASN.1 REAL numeral %q is invalid for NR3", numeral)
+		}
+		opts = _NR3ParseOptions
+	default:
+		return nil, errors.Newf("ASN.1 REAL form 0x%02X is invalid", contents[0])
+	}
+	opts.Limits = DecimalLimits
+	return ParseDecimalWith(numeral, opts)
+}
@@ -0,0 +1,100 @@
+package big
+
+import (
+	"strings"
+	"testing"
+
+	testing2 "github.com/golang-plus/testing"
+)
+
+func TestXSDAndASN1(t *testing.T) {
+	// test ParseXSDDecimal XSDDecimal
+	data := map[string]string{
+		"-1.23":           "-1.23",
+		"12678967.543233": "12678967.543233",
+		"+100000.00":      "100000.0",
+		"210":             "210.0",
+		".5":              "0.5",
+		"-.5":             "-0.5",
+		"5.":              "5.0",
+		"000123.4500":     "123.45",
+		"0":               "0.0",
+		"-0.0":            "0.0",
+	}
+	for k, v := range data {
+		d, err := ParseXSDDecimal(k)
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.XSDDecimal(), v)
+	}
+	for _, v := range []string{"", ".", "+", "1e5", "1.5E2", " 1", "1 ", "1,5", "INF", "NaN", "1_000", "--1"} {
+		_, err := ParseXSDDecimal(v)
+		testing2.AssertNotEqual(t, err, nil)
+	}
+	_, err := ParseXSDDecimal("-12.5e3")
+	testing2.AssertEqual(t, err, error(&ParseError{Input: "-12.5e3", Offset: 5, Reason: InvalidCharacter}))
+
+	// test MarshalASN1Real
+	data2 := map[string]string{
+		"1":      "\x09\x06\x031.E+0",
+		"10":     "\x09\x06\x031.E+1",
+		"0.5":    "\x09\x06\x035.E-1",
+		"-123.4": "\x09\x0A\x03-1234.E-1",
+		"-1200":  "\x09\x08\x03-12.E+2",
+		"0":      "\x09\x00",
+	}
+	for k, v := range data2 {
+		b := MarshalASN1Real(MustParseDecimal(k))
+		testing2.AssertEqual(t, string(b), v)
+		d, err := UnmarshalASN1Real(b)
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.String(), k)
+	}
+	long := strings.Repeat("7", 300)
+	d, err := UnmarshalASN1Real(MarshalASN1Real(MustParseDecimal(long)))
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, d.String(), long)
+	long = strings.Repeat("7", 70000) // long form length of 3 octets
+	b := MarshalASN1Real(MustParseDecimal(long))
+	testing2.AssertEqual(t, b[:5], []byte{0x09, 0x83, 0x01, 0x11, 0x75}) // 70005 contents octets
+	d, err = UnmarshalASN1Real(b)
+	testing2.AssertEqual(t, err, nil)
+	testing2.AssertEqual(t, d.String(), long)
+	testing2.AssertEqual(t, MarshalASN1Real(MustParseDecimal(strings.Repeat("7", 200)))[:3], []byte{0x09, 0x81, 0xCD})
+
+	// test UnmarshalASN1Real
+	data3 := map[string]string{
+		"\x09\x04\x01 42":     "42",
+		"\x09\x04\x01-42":     "-42",
+		"\x09\x05\x02+4,5":    "4.5",
+		"\x09\x03\x02.5":      "0.5",
+		"\x09\x03\x025.":      "5",
+		"\x09\x07\x03 1.5e3":  "1500",
+		"\x09\x08\x03-,25E-2": "-0.0025",
+		"\x09\x01\x43":        "0",
+	}
+	for k, v := range data3 {
+		d, err := UnmarshalASN1Real([]byte(k))
+		testing2.AssertEqual(t, err, nil)
+		testing2.AssertEqual(t, d.String(), v)
+	}
+	_, err = UnmarshalASN1Real([]byte("\x09\x01\x40"))
+	testing2.AssertEqual(t, err, ErrInfinity)
+	_, err = UnmarshalASN1Real([]byte("\x09\x01\x41"))
+	testing2.AssertEqual(t, err, ErrInfinity)
+	_, err = UnmarshalASN1Real([]byte("\x09\x01\x42"))
+	testing2.AssertEqual(t, err, ErrNaN)
+	for _, v := range []string{
+		"",
+		"\x02\x01\x01",         // INTEGER
+		"\x09\x03\x011.5",      // NR2 numeral in NR1 form
+		"\x09\x05\x021.5E1",    // NR3 numeral in NR2 form
+		"\x09\x04\x031.5",      // NR2 numeral in NR3 form
+		"\x09\x02\x0112",       // length mismatch
+		"\x09\x03\x04\x31\x32", // invalid form
+		"\x09\x03\x80\x01\x01", // binary encoding
+		"\x09\x81",
+	} {
+		_, err := UnmarshalASN1Real([]byte(v))
+		testing2.AssertNotEqual(t, err, nil)
+	}
+}